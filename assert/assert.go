@@ -1,8 +1,11 @@
 package assert
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
@@ -23,14 +26,46 @@ type TestContext interface {
 	// expected to meet certain criteria.
 	ThatActual(value interface{}) AssertableValue
 
+	// ThatActualCollection adapts the specified collection to an assertable
+	// one that's expected to meet certain criteria. The collection can be
+	// a slice, an array, a channel, or an iter.Seq.
+	ThatActualCollection(value interface{}) AssertableCollection
+
 	// ThatActualError adapts the specified error to an assertable one that's
 	// expected to meet certain criteria.
 	ThatActualError(value error) AssertableError
 
+	// ThatActualJSON adapts the specified JSON document, which can be a []byte,
+	// a string, or an io.Reader, to an assertable one that's expected to meet
+	// certain criteria.
+	ThatActualJSON(document interface{}) AssertableJSON
+
+	// ThatActualXML adapts the specified XML document, which can be a []byte,
+	// a string, or an io.Reader, to an assertable one that's expected to meet
+	// certain criteria.
+	ThatActualXML(document interface{}) AssertableXML
+
+	// ThatActualYAML adapts the specified YAML stream, which can be a []byte,
+	// a string, or an io.Reader, to an assertable one that's expected to meet
+	// certain criteria.
+	ThatActualYAML(stream interface{}) AssertableYAML
+
+	// ThatActualMap adapts the specified map to an assertable one that's
+	// expected to meet certain criteria.
+	ThatActualMap(value interface{}) AssertableMap
+
+	// ThatActualNumber adapts the specified number to an assertable one that's
+	// expected to meet certain criteria.
+	ThatActualNumber(value interface{}) AssertableNumber
+
 	// ThatActualString adapts the specified string to an assertable one that's
 	// expected to meet certain criteria.
 	ThatActualString(value string) AssertableString
 
+	// ThatActualBytes adapts the specified bytes to assertable ones that
+	// are expected to meet certain criteria.
+	ThatActualBytes(value []byte) AssertableBytes
+
 	// ThatActualTime adapts the specified time to an assertable one that's
 	// expected to meet certain criteria.
 	ThatActualTime(value *time.Time) AssertableTime
@@ -38,6 +73,27 @@ type TestContext interface {
 	// ThatType adapts the specified type to an assertable one that's
 	// expected to meet certain criteria.
 	ThatType(t reflect.Type) AssertableType
+
+	// Softly makes the assertions in the specified function softly; i.e.,
+	// the failures of assertions made via the TestContext passed to the
+	// function are collected rather than reported as they occur. Once the
+	// function returns, the collected failures, if any, are reported in one
+	// consolidated report; for example:
+	//     assert.For(t).Softly(func(a assert.TestContext) {
+	//         a.ThatActual(response.Code).Equals(200)
+	//         a.ThatActualString(response.Status).Equals("OK")
+	//     })
+	// Returns a SoftAssertionResult that provides post-assert actions.
+	Softly(assertions func(TestContext)) SoftAssertionResult
+
+	// VerifyNoGoroutineLeaks verifies, after the test and its cleanup
+	// functions registered later, that the goroutines started after this
+	// call have exited; see AssertableCall.DoesNotLeakGoroutines for how
+	// leaks are detected and for ignoring goroutines by their top functions.
+	// Goroutines that parallel tests start meanwhile are reported as well.
+	// It's typically called first thing in a test:
+	//     assert.For(t).VerifyNoGoroutineLeaks()
+	VerifyNoGoroutineLeaks(ignoredTopFunctions ...string)
 }
 
 // testContext decorates and extends testing.TB that's passed to test functions
@@ -47,6 +103,7 @@ type testContext struct {
 	parameters []interface{}
 	caller     func() (string, int) `test-hook:"verify-unexported"`
 	fail       func()               `test-hook:"verify-unexported"`
+	softReport *SoftAssertionReport // collects failures instead of reporting them, if set
 }
 
 const (
@@ -56,12 +113,53 @@ const (
 
 var printLock sync.Locker = &sync.Mutex{} // ensures that output is serialized
 
+var detectsCallers = flag.Bool("tester.detect-callers", false,
+	"print assertion failures to stdout, located by the tester's own caller detection, instead of logging them "+
+		"via testing.TB")
+
+var heapProfilesDirectory = flag.String("tester.heap-profiles", "",
+	"write a heap profile to the specified directory whenever an allocation or a heap growth budget is exceeded")
+
+var updatesBaselines = flag.Bool("tester.update-baselines", false,
+	"record the performance baselines that RunsWithin compares against instead of asserting on them")
+
+var updatesGolden = flag.Bool("tester.update", false,
+	"rewrite the golden files and record the snapshots that MatchesGoldenFile and MatchesSnapshot compare against "+
+		"instead of asserting on them, deleting the snapshots that tests no longer take")
+
+var cpuProfilesDirectory = flag.String("tester.cpu-profiles", "",
+	"write a CPU profile to the specified directory whenever a performance regression is detected")
+
 // For adapts from testing.TB to TestContext in order to allow
 // the latter to assert on behalf of the former.
 // The optional parameter(s) can be used to identify a specific test case
 // in a data-driven test.
 func For(t testing.TB, parameters ...interface{}) TestContext {
-	return &testContext{t, parameters, caller, t.Fail}
+	return newTestContext(t, t.Fail, parameters)
+}
+
+// Require adapts from testing.TB to TestContext like For does, except that
+// a failed assertion stops the test by calling t.FailNow after reporting the
+// failure; for example:
+//     user, err := store.GetUser(id)
+//     assert.Require(t).ThatActualError(err).IsNil()
+//     assert.For(t).ThatActualString(user.Name).Equals("Ann") // user isn't nil
+//
+// Like t.FailNow, failed assertions stop only the goroutine that runs the
+// test, subtest, or cleanup function of the specified testing.TB; hence,
+// a TestContext returned by Require must not be used in other goroutines,
+// such as ones that a test starts, where For should be used instead.
+// Failures of soft assertions stop the test once the Softly block is done.
+func Require(t testing.TB, parameters ...interface{}) TestContext {
+	return newTestContext(t, t.FailNow, parameters)
+}
+
+func newTestContext(t testing.TB, fail func(), parameters []interface{}) *testContext {
+	testContext := &testContext{TB: t, parameters: parameters, fail: fail}
+	if *detectsCallers {
+		testContext.caller = caller
+	}
+	return testContext
 }
 
 func (testContext *testContext) ThatCalling(call func()) AssertableCall {
@@ -72,14 +170,42 @@ func (testContext *testContext) ThatActual(value interface{}) AssertableValue {
 	return &assertableValue{testContext: testContext, value: value}
 }
 
+func (testContext *testContext) ThatActualCollection(value interface{}) AssertableCollection {
+	return newAssertableCollection(testContext, value)
+}
+
 func (testContext *testContext) ThatActualError(value error) AssertableError {
 	return &assertableError{testContext: testContext, value: value}
 }
 
+func (testContext *testContext) ThatActualJSON(document interface{}) AssertableJSON {
+	return newAssertableJSON(testContext, document)
+}
+
+func (testContext *testContext) ThatActualXML(document interface{}) AssertableXML {
+	return newAssertableXML(testContext, document)
+}
+
+func (testContext *testContext) ThatActualYAML(stream interface{}) AssertableYAML {
+	return newAssertableYAML(testContext, stream)
+}
+
+func (testContext *testContext) ThatActualMap(value interface{}) AssertableMap {
+	return &assertableMap{testContext: testContext, value: value}
+}
+
+func (testContext *testContext) ThatActualNumber(value interface{}) AssertableNumber {
+	return &assertableNumber{testContext: testContext, value: value}
+}
+
 func (testContext *testContext) ThatActualString(value string) AssertableString {
 	return &assertableString{testContext: testContext, value: value}
 }
 
+func (testContext *testContext) ThatActualBytes(value []byte) AssertableBytes {
+	return &assertableBytes{testContext: testContext, value: value}
+}
+
 func (testContext *testContext) ThatActualTime(value *time.Time) AssertableTime {
 	return &assertableTime{testContext: testContext, value: value}
 }
@@ -88,43 +214,118 @@ func (testContext *testContext) ThatType(t reflect.Type) AssertableType {
 	return &assertableType{testContext: testContext, Type: t}
 }
 
-// PrintDiff prints a pretty diff of the specified actual and expected values,
-// in that order.
+// PrintDiff prints a structural diff of the specified actual and expected
+// values, in that order, to stdout; one line per differing leaf, labeled by
+// its path. LogDiff logs the same diff via a test instead.
 func PrintDiff(actual interface{}, expected interface{}) {
 	printLock.Lock()
 	defer printLock.Unlock()
+	fmt.Fprint(stdout(), formatDiff(actual, expected))
+}
 
-	fmt.Println("Diff:")
-	pretty.Fdiff(os.Stdout, actual, expected)
+// LogDiff logs a structural diff of the specified actual and expected values,
+// in that order, via the specified test, which attributes the diff to it as
+// ValueAssertionResult.ThenDiffOnFail does.
+func LogDiff(t testing.TB, actual interface{}, expected interface{}) {
+	t.Helper()
+	newTestContext(t, t.Fail, nil).log("", noCallerInfoLineNumber, formatDiff(actual, expected))
 }
 
 // PrettyPrint pretty-prints the specified actual and expected values,
-// in that order.
+// in that order, to stdout. LogPretty logs the same via a test instead.
 func PrettyPrint(actual interface{}, expected interface{}) {
 	printLock.Lock()
 	defer printLock.Unlock()
-	pretty.Printf("Pretty:\nActual: %s\nExpected: %s\n", actual, expected)
+	fmt.Fprint(stdout(), formatPretty(actual, expected))
+}
+
+// LogPretty pretty-prints the specified actual and expected values, in that
+// order, via the specified test, which attributes them to it as
+// ValueAssertionResult.ThenPrettyPrintOnFail does.
+func LogPretty(t testing.TB, actual interface{}, expected interface{}) {
+	t.Helper()
+	newTestContext(t, t.Fail, nil).log("", noCallerInfoLineNumber, formatPretty(actual, expected))
+}
+
+func formatDiff(actual interface{}, expected interface{}) string {
+	return "Diff:\n" + formatDifferences(diffValues(actual, expected, newEqualityOptions()))
+}
+
+func formatPretty(actual interface{}, expected interface{}) string {
+	return pretty.Sprintf("Pretty:\nActual: %s\nExpected: %s\n", actual, expected)
 }
 
 func (testContext *testContext) decoratedErrorf(format string, args ...interface{}) {
-	file, line := testContext.caller()
+	testContext.Helper()
+	file, line := testContext.location()
 	testContext.errorf(file, line, format, args...)
 }
 
+// location returns the file and line of the assertion being made if either
+// caller detection is used, the assertion is soft, or it's made while a call
+// is stressed; otherwise, the test locates failures itself.
+func (testContext *testContext) location() (string, int) {
+	switch {
+	case testContext.caller != nil:
+		return testContext.caller()
+	case testContext.softReport != nil || currentStressWorker() != nil:
+		file, line := caller()
+		return filepath.Base(file), line // like the test's own locations
+	}
+	return "", noCallerInfoLineNumber
+}
+
 func (testContext *testContext) errorf(file string, line int, format string, args ...interface{}) {
+	testContext.Helper()
+	message := fmt.Sprintf(format, args...)
+	if len(testContext.parameters) > 0 {
+		message = fmt.Sprint(testContext.parameters, " ") + message
+	}
+	if testContext.softReport != nil {
+		testContext.softReport.add(&SoftAssertionFailure{File: file, Line: line, Message: message})
+		return
+	}
+	if worker := currentStressWorker(); worker != nil {
+		worker.add(&SoftAssertionFailure{File: file, Line: line, Message: message})
+		return
+	}
+
+	testContext.log(file, line, message)
+	testContext.fail()
+}
+
+// log logs the specified message via the test, which attributes it to the
+// test and the line that made the assertion; while the stdout of a call is
+// captured, it's logged after the capture ends. If caller detection is used,
+// it prints the message to stdout instead, prefixed by the specified file and
+// line, if known.
+func (testContext *testContext) log(file string, line int, message string) {
+	testContext.Helper()
+	if testContext.caller == nil {
+		message = strings.TrimSuffix(message, "\n")
+		if !holdLogWhileStdoutIsCaptured(func() { testContext.Helper(); testContext.Log(message) }) {
+			testContext.Log(message)
+		}
+		return
+	}
+
 	printLock.Lock()
 	defer printLock.Unlock()
 
 	if line != noCallerInfoLineNumber {
-		fmt.Printf("%s:%d: ", file, line) // because t.Errorf prints out the wrong file and line info
+		fmt.Fprintf(stdout(), "%s:%d: ", file, line)
 	}
+	fmt.Fprint(stdout(), message)
+}
 
-	if len(testContext.parameters) > 0 {
-		fmt.Print(testContext.parameters, " ")
+// stdout returns the stdout to print to; i.e., the stdout of the process
+// even while the stdout of a call is captured. It must be called while
+// holding printLock.
+func stdout() io.Writer {
+	if uncapturedStdout != nil {
+		return uncapturedStdout
 	}
-
-	fmt.Printf(format, args...)
-	testContext.fail()
+	return os.Stdout
 }
 
 func caller() (file string, line int) {
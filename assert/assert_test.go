@@ -1,6 +1,7 @@
 package assert
 
 import (
+	"fmt"
 	"reflect"
 	"runtime"
 	"testing"
@@ -22,6 +23,48 @@ func TestCaller(t *testing.T) {
 	For(t).ThatActual(line).Equals(expectedLine)
 }
 
+// logRecorder records what's logged via testing.TB and whether the test
+// failed.
+type logRecorder struct {
+	testing.TB
+	logs   []string
+	failed bool
+}
+
+func (recorder *logRecorder) Log(args ...interface{}) {
+	recorder.logs = append(recorder.logs, fmt.Sprint(args...))
+}
+
+func (recorder *logRecorder) Fail() {
+	recorder.failed = true
+}
+
+func TestFailuresAreLoggedViaTheTest(t *testing.T) {
+	recorder, line := &logRecorder{TB: t}, 0
+	For(recorder, "case").ThatActual(42).Equals(13).ThenDiffOnFail()
+	For(recorder).Softly(func(a TestContext) {
+		_, _, line, _ = runtime.Caller(0)
+		a.ThatActual(true).IsFalse()
+	})
+
+	For(t).ThatActual(recorder.failed).IsTrue()
+	For(t).ThatActualCollection(recorder.logs).ContainsInOrder(
+		"[case] Value mismatch.\nActual: 42\nExpected: 13",
+		"Diff:\nActual: 42 != 13",
+		fmt.Sprintf("Soft assertions failed (1 failure).\nassert_test.go:%d: Value mismatch.\n"+
+			"Actual: true\nExpected: false", line+1))
+}
+
+func TestDiffsAreLoggedViaTheTest(t *testing.T) {
+	recorder := &logRecorder{TB: t}
+	LogDiff(recorder, 42, 13)
+	LogPretty(recorder, "foo", "bar")
+
+	For(t).ThatActual(recorder.failed).IsFalse()
+	For(t).ThatActual(recorder.logs).Equals(
+		[]string{"Diff:\nActual: 42 != 13", "Pretty:\nActual: foo\nExpected: bar"})
+}
+
 func pack(elements ...interface{}) []interface{} {
 	return elements
 }
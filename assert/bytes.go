@@ -0,0 +1,38 @@
+package assert
+
+import "bytes"
+
+// AssertableBytes represents under-test bytes that are expected to meet
+// certain criteria.
+type AssertableBytes interface {
+	// Equals asserts that the specified actual bytes equal the expected ones.
+	// A nil slice equals an empty one.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Equals(expected []byte) ValueAssertionResult
+
+	// MatchesGoldenFile asserts that the specified actual bytes equal the
+	// content of the specified golden file of the test; see
+	// AssertableString.MatchesGoldenFile. Mismatches of text are reported as
+	// line diffs, and of binary content by the first differing byte.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MatchesGoldenFile(name string) ValueAssertionResult
+}
+
+type assertableBytes struct {
+	testContext *testContext
+	value       []byte
+}
+
+func (actual *assertableBytes) Equals(expected []byte) ValueAssertionResult {
+	actual.testContext.Helper()
+	areEqual := bytes.Equal(actual.value, expected)
+	if !areEqual {
+		actual.testContext.decoratedErrorf("Bytes mismatch.\n%s", formatContentDiff(actual.value, expected))
+	}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
+}
+
+func (actual *assertableBytes) MatchesGoldenFile(name string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.testContext.matchesGoldenFile(actual.value, name)
+}
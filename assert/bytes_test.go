@@ -0,0 +1,23 @@
+package assert
+
+import "fmt"
+
+func ExampleAssertableBytes_Equals_pass() {
+	if For(t).ThatActualBytes([]byte("foo")).Equals([]byte("foo")).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableBytes_Equals_fail() {
+	mockTestContextToAssert("text").ThatActualBytes([]byte("foo")).Equals([]byte("bar"))
+	mockTestContextToAssert("binary").ThatActualBytes([]byte{0xff, 0x00}).Equals([]byte{0xff})
+	// Output:
+	// file:3: [text] Bytes mismatch.
+	// Actual: "foo"
+	// Expected: "bar"
+	// file:3: [binary] Bytes mismatch.
+	// Actual: 2 B
+	// Expected: 1 B
+	// First difference: byte 1; actual 0x00, expected end of content
+}
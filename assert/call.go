@@ -1,13 +1,168 @@
 package assert
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+)
 
 // AssertableCall represents an under-test function call that's expected
 // to meet certain criteria.
 type AssertableCall interface {
+	// DoesNotPanic asserts that calling the specified callable doesn't cause
+	// a panic; otherwise, the panic value and the stack of the panicking
+	// goroutine are reported.
+	// Returns a PanicAssertionResult that provides post-assert actions.
+	DoesNotPanic() PanicAssertionResult
+
+	// Panics asserts that calling the specified callable causes a panic with
+	// any value.
+	// Returns a PanicAssertionResult that provides post-assert actions.
+	Panics() PanicAssertionResult
+
 	// PanicsReporting asserts that calling the specified callable causes
-	// a panic with the specified expected error.
-	PanicsReporting(interface{})
+	// a panic with the specified expected error; i.e., a value that
+	// formats like it.
+	// Returns a PanicAssertionResult that provides post-assert actions.
+	PanicsReporting(expectedError interface{}) PanicAssertionResult
+
+	// PanicsWithErrorMatching asserts that calling the specified callable
+	// causes a panic with an error whose message matches the specified
+	// regular expression.
+	// See https://golang.org/pkg/regexp/syntax/ for the syntax.
+	// Returns a PanicAssertionResult that provides post-assert actions.
+	PanicsWithErrorMatching(pattern string) PanicAssertionResult
+
+	// PanicsWithValueOfType asserts that calling the specified callable
+	// causes a panic with a value that's assignable to the specified type;
+	// e.g., reflect.TypeOf((*error)(nil)).Elem() for any error.
+	// Returns a PanicAssertionResult that provides post-assert actions.
+	PanicsWithValueOfType(expectedType reflect.Type) PanicAssertionResult
+
+	// PanicsWithErrorIs asserts that calling the specified callable causes
+	// a panic with an error that matches the specified target as reported
+	// by errors.Is.
+	// Returns a PanicAssertionResult that provides post-assert actions.
+	PanicsWithErrorIs(target error) PanicAssertionResult
+
+	// CompletesWithin asserts that calling the specified callable returns
+	// within the specified timeout. The call is made in a new goroutine; if
+	// it times out, it's left running, and the stacks of its goroutine and of
+	// the goroutines that it started are reported. If it's still running
+	// after the test, that's logged as well. A call that exits via
+	// runtime.Goexit (e.g., via t.FailNow) rather than returning fails.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	CompletesWithin(timeout time.Duration) ValueAssertionResult
+
+	// BlocksForAtLeast asserts that calling the specified callable neither
+	// returns nor panics for at least the specified duration; e.g., that it
+	// blocks on a channel or a lock. The call is made in a new goroutine and
+	// is left running; if it's still running after the test, that's logged.
+	// A call that exits via runtime.Goexit (e.g., via t.FailNow) fails.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	BlocksForAtLeast(duration time.Duration) ValueAssertionResult
+
+	// DoesNotLeakGoroutines asserts that the goroutines that calling the
+	// specified callable starts exit soon after the call returns; they're
+	// given time to settle, with exponential backoff, before the ones left
+	// running are reported, grouped by where they were created. Goroutines
+	// whose top functions are among the specified ones are ignored; e.g.,
+	// "net/http.(*persistConn).readLoop".
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	DoesNotLeakGoroutines(ignoredTopFunctions ...string) ValueAssertionResult
+
+	// AllocatesAtMost asserts that calling the specified callable allocates
+	// at most the specified number of times per run on average, as measured
+	// by testing.AllocsPerRun. If the budget is exceeded and tests are run
+	// with the -tester.heap-profiles flag, a heap profile is written to the
+	// directory that the flag specifies.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	AllocatesAtMost(allocations float64) ValueAssertionResult
+
+	// DoesNotGrowHeapBy asserts that calling the specified callable the
+	// specified number of times grows the heap, as measured after garbage
+	// collection, by at most the specified number of bytes; e.g., that the
+	// callable doesn't retain what it allocates. If the budget is exceeded
+	// and tests are run with the -tester.heap-profiles flag, a heap profile
+	// is written to the directory that the flag specifies.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	DoesNotGrowHeapBy(bytes int64, iterations int) ValueAssertionResult
+
+	// RunsWithin asserts that calling the specified callable hasn't become
+	// slower than the named baseline by more than the specified tolerance
+	// (e.g., 0.1 for 10%), with 95% confidence. The callable is sampled by
+	// running testing.Benchmark 5 times, and the samples are compared
+	// against the baseline stored in "_baselines/<baseline name>.json",
+	// next to the test, using Welch's t-test. As each benchmark runs for
+	// about the -test.benchtime (1s by default), an assertion takes at least
+	// about 5s; hence, it's best kept out of short test runs (see
+	// testing.Short). Baselines are recorded, rather than asserted on, when
+	// tests are run with the -tester.update-baselines flag. If a regression
+	// is detected and tests are run with the -tester.cpu-profiles flag,
+	// a CPU profile of another benchmark is written to the directory that
+	// the flag specifies.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	RunsWithin(baselineName string, tolerance float64) ValueAssertionResult
+
+	// WritesToStdout calls the specified callable while capturing what it,
+	// the goroutines it starts, and its child processes write to stdout, and
+	// adapts the captured output to an assertable string. The file descriptor
+	// of stdout is redirected (on Unix-like systems; os.Stdout is elsewhere),
+	// so writers that were created before the call are captured too. As the
+	// stream is global to the process, captures are serialized, the callable
+	// mustn't capture output itself, and the assertion mustn't be made in
+	// parallel tests (see testing.T.Parallel), whose output may be captured.
+	// The tester's own output is never captured; failures logged during the
+	// call are logged after it. The stream is restored even if the call exits
+	// via runtime.Goexit (e.g., via t.FailNow), which fails the assertion.
+	WritesToStdout() AssertableString
+
+	// WritesToStderr calls the specified callable while capturing what it,
+	// the goroutines it starts, and its child processes write to stderr, and
+	// adapts the captured output to an assertable string. Like stdout, the
+	// file descriptor of stderr is redirected, and the assertion mustn't be
+	// made in parallel tests.
+	WritesToStderr() AssertableString
+
+	// LogsMatching calls the specified callable while capturing what it,
+	// the goroutines it starts, and its child processes log; i.e., write to
+	// stderr, where loggers write by default, or via the standard logger of
+	// the log package, wherever it writes. It asserts that the captured
+	// output matches the specified regular expression, and adapts the output
+	// to an assertable string. Like stdout, the file descriptor of stderr is
+	// redirected, and the assertion mustn't be made in parallel tests.
+	LogsMatching(pattern string) AssertableString
+
+	// IsSafeUnderConcurrency asserts that calling the specified callable
+	// the specified number of iterations from each of the specified number
+	// of goroutines, which start together, neither panics nor fails any
+	// assertion that it makes. The goroutines run for several rounds, each
+	// with a different GOMAXPROCS, and the specified invariants, if any, are
+	// checked after each round; e.g., to verify that a cache didn't exceed
+	// its capacity. Failures are reported together, labeled by round and by
+	// goroutine index. Since GOMAXPROCS is global, such tests shouldn't run
+	// in parallel with others.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsSafeUnderConcurrency(goroutines, iterations int, invariants ...func(TestContext)) ValueAssertionResult
+}
+
+// PanicAssertionResult represents operations that may be performed on
+// the result of a panic assertion; for example:
+//
+//     var pathError *fs.PathError
+//     recovered := assert.For(t).ThatCalling(fn).Panics().Recovered()
+//     assert.For(t).ThatActualError(recovered.(error)).As(&pathError)
+type PanicAssertionResult interface {
+	ValueAssertionResult
+
+	// Recovered returns the value that the call panicked with, or nil if
+	// the call didn't panic.
+	Recovered() interface{}
 }
 
 type assertableCall struct {
@@ -15,18 +170,257 @@ type assertableCall struct {
 	call        func()
 }
 
-func (callable *assertableCall) PanicsReporting(expectedError interface{}) {
-	file, line := callable.testContext.caller() // must be set here to capture the right stack frame
+type panicAssertionResult struct {
+	*valueAssertionResult
+	recovered interface{}
+}
+
+// goroutineCall is a call that's made in a new goroutine; its outcome and
+// duration are set before done is closed. The outcome is nil if the call
+// didn't return, as it exited via runtime.Goexit (e.g., t.FailNow).
+type goroutineCall struct {
+	goroutineID int
+	outcome     *callOutcome
+	returned    bool
+	duration    time.Duration
+	done        chan struct{}
+}
+
+// callOutcome is the outcome of a call; i.e., whether it panicked, and if so,
+// with which value and stack.
+type callOutcome struct {
+	recovered   interface{}
+	stack       string
+	hasPanicked bool
+}
+
+func (callable *assertableCall) DoesNotPanic() PanicAssertionResult {
+	callable.testContext.Helper()
+	outcome := recoverCall(callable.call)
+	if outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call panicked unexpectedly.\nPanic: %v\n%s",
+			outcome.recovered, outcome.formatStack())
+	}
+	return callable.resultOf(!outcome.hasPanicked, outcome, nil)
+}
+
+func (callable *assertableCall) Panics() PanicAssertionResult {
+	callable.testContext.Helper()
+	outcome := recoverCall(callable.call)
+	if !outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call did not panic as expected.\n")
+	}
+	return callable.resultOf(outcome.hasPanicked, outcome, &anyOtherValue{})
+}
+
+func (callable *assertableCall) PanicsReporting(expectedError interface{}) PanicAssertionResult {
+	callable.testContext.Helper()
+	outcome := recoverCall(callable.call)
+	if !outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call did not panic as expected.\nExpected: %s\n", expectedError)
+		return callable.resultOf(false, outcome, expectedError)
+	}
+
+	passed := fmt.Sprint(outcome.recovered) == fmt.Sprint(expectedError)
+	if !passed {
+		callable.testContext.decoratedErrorf("Panic message mismatch.\nActual: %s\nExpected: %s\n%s",
+			outcome.recovered, expectedError, outcome.formatStack())
+	}
+	return callable.resultOf(passed, outcome, expectedError)
+}
+
+func (callable *assertableCall) PanicsWithErrorMatching(pattern string) PanicAssertionResult {
+	callable.testContext.Helper()
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		callable.testContext.decoratedErrorf("Invalid regular expression.\nError: %v\n", err)
+		return callable.resultOf(false, &callOutcome{}, pattern)
+	}
+
+	outcome := recoverCall(callable.call)
+	if err, passed := callable.panickedWithError(outcome, pattern); !passed {
+		return callable.resultOf(false, outcome, pattern)
+	} else if !regex.MatchString(err.Error()) {
+		callable.testContext.decoratedErrorf("Panic message mismatch.\nActual: %s\nExpected to match: %s\n%s",
+			err, pattern, outcome.formatStack())
+		return callable.resultOf(false, outcome, pattern)
+	}
+	return callable.resultOf(true, outcome, pattern)
+}
+
+func (callable *assertableCall) PanicsWithValueOfType(expectedType reflect.Type) PanicAssertionResult {
+	callable.testContext.Helper()
+	outcome := recoverCall(callable.call)
+	if !outcome.hasPanicked {
+		callable.testContext.decoratedErrorf(
+			"Function call did not panic as expected.\nExpected type: %s\n", expectedType)
+		return callable.resultOf(false, outcome, expectedType)
+	}
+
+	actualType := reflect.TypeOf(outcome.recovered)
+	passed := actualType != nil && actualType.AssignableTo(expectedType)
+	if !passed {
+		callable.testContext.decoratedErrorf("Panic value type mismatch.\nActual: %s (%v)\nExpected: %s\n%s",
+			actualType, outcome.recovered, expectedType, outcome.formatStack())
+	}
+	return callable.resultOf(passed, outcome, expectedType)
+}
+
+func (callable *assertableCall) PanicsWithErrorIs(target error) PanicAssertionResult {
+	callable.testContext.Helper()
+	outcome := recoverCall(callable.call)
+	if err, passed := callable.panickedWithError(outcome, formatError(target)); !passed {
+		return callable.resultOf(false, outcome, target)
+	} else if !errors.Is(err, target) {
+		callable.testContext.decoratedErrorf("Panic error chain mismatch.\nActual chain:\n%sExpected to find: %s\n%s",
+			formatErrorChain(err), formatError(target), outcome.formatStack())
+		return callable.resultOf(false, outcome, target)
+	}
+	return callable.resultOf(true, outcome, target)
+}
+
+func (callable *assertableCall) CompletesWithin(timeout time.Duration) ValueAssertionResult {
+	callable.testContext.Helper()
+	call := callable.startInGoroutine()
+	if !call.completesWithin(timeout) {
+		file, line := caller()
+		stacks := goroutineFamily(allGoroutineStacks(), call.goroutineID)
+		callable.testContext.decoratedErrorf(
+			"Function call did not complete within %v.\nGoroutines of the call asserted at %s:%d:\n%s",
+			timeout, filepath.Base(file), line, formatGoroutineStacks(stacks))
+		callable.logIfRunningAfterTest(call, file, line)
+		return callable.testContext.resultOf(false, nil, timeout)
+	} else if !call.returned {
+		callable.testContext.decoratedErrorf("Function call exited via runtime.Goexit instead of returning.\n"+
+			"Actual: exited after %v (e.g., via t.FailNow or t.SkipNow)\n", call.duration)
+		return callable.testContext.resultOf(false, call.duration, timeout)
+	} else if call.outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call panicked unexpectedly.\nPanic: %v\n%s",
+			call.outcome.recovered, call.outcome.formatStack())
+		return callable.testContext.resultOf(false, call.duration, timeout)
+	}
+	return callable.testContext.resultOf(true, call.duration, timeout)
+}
+
+func (callable *assertableCall) BlocksForAtLeast(duration time.Duration) ValueAssertionResult {
+	callable.testContext.Helper()
+	call := callable.startInGoroutine()
+	if !call.completesWithin(duration) {
+		file, line := caller()
+		callable.logIfRunningAfterTest(call, file, line)
+		return callable.testContext.resultOf(true, nil, duration)
+	} else if !call.returned {
+		callable.testContext.decoratedErrorf(
+			"Function call exited via runtime.Goexit instead of blocking for at least %v.\n"+
+				"Actual: exited after %v (e.g., via t.FailNow or t.SkipNow)\n", duration, call.duration)
+	} else if call.outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call panicked instead of blocking for at least %v.\n"+
+			"Panic: %v\n%s", duration, call.outcome.recovered, call.outcome.formatStack())
+	} else {
+		callable.testContext.decoratedErrorf(
+			"Function call did not block for at least %v.\nActual: returned after %v\n", duration, call.duration)
+	}
+	return callable.testContext.resultOf(false, call.duration, duration)
+}
+
+func (callable *assertableCall) startInGoroutine() *goroutineCall {
+	call := &goroutineCall{done: make(chan struct{})}
+	goroutineIDs := make(chan int)
+	go func() {
+		defer close(call.done)
+		goroutineIDs <- currentGoroutineID()
+		start := time.Now()
+		defer func() { call.duration = time.Since(start) }() // even if the call exits via runtime.Goexit
+		call.outcome = recoverCall(callable.call)
+		call.returned = true
+	}()
+	call.goroutineID = <-goroutineIDs
+	return call
+}
+
+// completesWithin returns true if the call completes within the specified
+// timeout.
+func (call *goroutineCall) completesWithin(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-call.done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// logIfRunningAfterTest logs, after the test, whether the specified call,
+// whose assertion's location is specified, is still running.
+func (callable *assertableCall) logIfRunningAfterTest(call *goroutineCall, file string, line int) {
+	callable.testContext.Cleanup(func() {
+		select {
+		case <-call.done:
+		default:
+			callable.testContext.log("", noCallerInfoLineNumber, fmt.Sprintf(
+				"Function call asserted at %s:%d is still running after the test (goroutine %d).\n",
+				filepath.Base(file), line, call.goroutineID))
+		}
+	})
+}
+
+// panickedWithError returns the error that the call panicked with, and
+// whether it did; otherwise, it reports the failure of the assertion whose
+// expectation is specified.
+func (callable *assertableCall) panickedWithError(outcome *callOutcome, expected string) (error, bool) {
+	callable.testContext.Helper()
+	if !outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call did not panic as expected.\nExpected: %s\n", expected)
+		return nil, false
+	}
+	err, isError := outcome.recovered.(error)
+	if !isError {
+		callable.testContext.decoratedErrorf("Panic value is not an error.\nActual: %v (%T)\nExpected: %s\n%s",
+			outcome.recovered, outcome.recovered, expected, outcome.formatStack())
+	}
+	return err, isError
+}
 
+func (callable *assertableCall) resultOf(passed bool, outcome *callOutcome, expected interface{}) PanicAssertionResult {
+	return &panicAssertionResult{
+		valueAssertionResult: callable.testContext.resultOf(passed, outcome.recovered, expected),
+		recovered:            outcome.recovered,
+	}
+}
+
+func (result *panicAssertionResult) Recovered() interface{} {
+	return result.recovered
+}
+
+// recoverCall makes the specified call and returns its outcome, including
+// the value it panicked with, if it panicked. Failures are reported after the
+// call returns so that the panicking frames don't get attributed the failures.
+func recoverCall(call func()) (outcome *callOutcome) {
+	outcome = &callOutcome{hasPanicked: true}
 	defer func() {
-		if err := recover(); err == nil {
-			callable.testContext.errorf(
-				file, line, "Function call did not panic as expected.\nExpected: %s\n", expectedError)
-		} else if fmt.Sprint(err) != fmt.Sprint(expectedError) {
-			callable.testContext.errorf(
-				file, line, "Panic message mismatch.\nActual: %s\nExpected: %s\n", err, expectedError)
+		if outcome.recovered = recover(); outcome.hasPanicked {
+			outcome.stack = panicStack(debug.Stack())
 		}
 	}()
+	call()
+	outcome.hasPanicked = false
+	return outcome
+}
+
+// panicStack trims the specified stack of a goroutine that's recovering from
+// a panic to start with the panic; i.e., it drops the frames that captured
+// the stack in a deferred function.
+func panicStack(stack []byte) string {
+	lines := strings.Split(strings.TrimSuffix(string(stack), "\n"), "\n")
+	for i, line := range lines {
+		if i > 0 && strings.HasPrefix(line, "panic(") {
+			return lines[0] + "\n" + strings.Join(lines[i:], "\n") + "\n"
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
 
-	callable.call()
+func (outcome *callOutcome) formatStack() string {
+	return "Stack:\n" + outcome.stack
 }
@@ -1,5 +1,41 @@
 package assert
 
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleAssertableCall_DoesNotPanic_pass() {
+	if For(t).ThatCalling(func() {}).DoesNotPanic().Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_Panics_pass() {
+	result := For(t).ThatCalling(func() { panic(42) }).Panics()
+	if result.Passed() {
+		fmt.Println("Recovered:", result.Recovered())
+	}
+	// Output: Recovered: 42
+}
+
+func ExampleAssertableCall_Panics_fail() {
+	result := mockTestContextToAssert().ThatCalling(func() {}).Panics()
+	if !result.Passed() {
+		fmt.Println("Recovered:", result.Recovered())
+	}
+	// Output:
+	// file:3: Function call did not panic as expected.
+	// Recovered: <nil>
+}
+
 func ExampleAssertableCall_PanicsReporting_pass() {
 	For(t).ThatCalling(func() { panic("error") }).PanicsReporting("error")
 	// Output:
@@ -12,10 +48,206 @@ func ExampleAssertableCall_PanicsReporting_panicDidNotOccur() {
 	// Expected: expected
 }
 
-func ExampleAssertableCall_PanicsReporting_messageMismatch() {
-	mockTestContextToAssert().ThatCalling(func() { panic("actual") }).PanicsReporting("expected")
+func ExampleAssertableCall_PanicsWithErrorMatching_pass() {
+	result := For(t).ThatCalling(func() { _ = []int{}[1] }).PanicsWithErrorMatching(`index out of range \[\d+\]`)
+	if result.Passed() {
+		fmt.Println("Recovered:", result.Recovered())
+	}
+	// Output: Recovered: runtime error: index out of range [1] with length 0
+}
+
+func ExampleAssertableCall_PanicsWithErrorMatching_fail() {
+	cases := []struct {
+		id      string
+		call    func()
+		pattern string
+	}{
+		{"no panic", func() {}, "^foo$"},
+		{"invalid pattern", func() { panic(errors.New("foo")) }, "(foo"},
+	}
+
+	for _, c := range cases {
+		mockTestContextToAssert(c.id).ThatCalling(c.call).PanicsWithErrorMatching(c.pattern)
+	}
 	// Output:
-	// file:3: Panic message mismatch.
-	// Actual: actual
-	// Expected: expected
+	// file:3: [no panic] Function call did not panic as expected.
+	// Expected: ^foo$
+	// file:3: [invalid pattern] Invalid regular expression.
+	// Error: error parsing regexp: missing closing ): `(foo`
+}
+
+func ExampleAssertableCall_PanicsWithValueOfType_pass() {
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	cases := []struct {
+		id           string
+		call         func()
+		expectedType reflect.Type
+	}{
+		{"concrete type", func() { panic("foo") }, reflect.TypeOf("")},
+		{"interface", func() { panic(fs.ErrNotExist) }, errorType},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatCalling(c.call).PanicsWithValueOfType(c.expectedType).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: concrete type
+	// Passed: interface
+}
+
+func ExampleAssertableCall_PanicsWithValueOfType_fail() {
+	mockTestContextToAssert().ThatCalling(func() {}).PanicsWithValueOfType(reflect.TypeOf(""))
+	// Output:
+	// file:3: Function call did not panic as expected.
+	// Expected type: string
+}
+
+func ExampleAssertableCall_PanicsWithErrorIs_pass() {
+	result := For(t).ThatCalling(func() { panic(fmt.Errorf("load config: %w", fs.ErrNotExist)) }).
+		PanicsWithErrorIs(fs.ErrNotExist)
+	if result.Passed() {
+		fmt.Println("Recovered:", result.Recovered())
+	}
+	// Output: Recovered: load config: file does not exist
+}
+
+func ExampleAssertableCall_PanicsWithErrorIs_fail() {
+	mockTestContextToAssert().ThatCalling(func() {}).PanicsWithErrorIs(fs.ErrNotExist)
+	// Output:
+	// file:3: Function call did not panic as expected.
+	// Expected: "file does not exist" (*errors.errorString)
+}
+
+func ExampleAssertableCall_CompletesWithin_pass() {
+	if For(t).ThatCalling(func() {}).CompletesWithin(time.Second).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_BlocksForAtLeast_pass() {
+	release := make(chan struct{})
+	defer close(release)
+	if For(t).ThatCalling(func() { <-release }).BlocksForAtLeast(10 * time.Millisecond).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func TestCompletesWithinReportsTheGoroutinesOfTheCallOnTimeout(t *testing.T) {
+	release, unrelated := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	defer close(unrelated)
+	go blockUntilClosed(unrelated)
+
+	recorder, line := &logRecorder{}, 0
+	t.Run("timeout", func(t *testing.T) {
+		recorder.TB = t
+		call := func() { go blockUntilClosed(release); blockUntilClosed(release) }
+		_, _, line, _ = runtime.Caller(0)
+		For(recorder).ThatCalling(call).CompletesWithin(50 * time.Millisecond)
+	})
+
+	location := fmt.Sprintf("call_test.go:%d", line+1)
+	if For(t).ThatActualCollection(recorder.logs).HasLength(2).Passed() {
+		message := recorder.logs[0]
+		For(t).ThatActual(strings.HasPrefix(message,
+			"Function call did not complete within 50ms.\nGoroutines of the call asserted at "+location+":\n")).IsTrue()
+		For(t).ThatActual(strings.Count(message, "\ngoroutine ")).Equals(2) // the call's and the one it started
+		For(t).ThatActual(strings.Count(message, "assert.blockUntilClosed(")).Equals(2)
+		For(t).ThatActual(strings.HasPrefix(recorder.logs[1],
+			"Function call asserted at "+location+" is still running after the test (goroutine ")).IsTrue()
+	}
+}
+
+func TestCallTimingFailures(t *testing.T) {
+	cases := []struct {
+		id       string
+		assert   func(call AssertableCall) ValueAssertionResult
+		call     func()
+		expected string
+	}{
+		{"CompletesWithin panic",
+			func(call AssertableCall) ValueAssertionResult { return call.CompletesWithin(time.Minute) },
+			func() { panicWith("boom") },
+			"Function call panicked unexpectedly.\nPanic: boom\nStack:\ngoroutine "},
+		{"BlocksForAtLeast panic",
+			func(call AssertableCall) ValueAssertionResult { return call.BlocksForAtLeast(time.Minute) },
+			func() { panicWith("boom") },
+			"Function call panicked instead of blocking for at least 1m0s.\nPanic: boom\nStack:\ngoroutine "},
+		{"BlocksForAtLeast returned",
+			func(call AssertableCall) ValueAssertionResult { return call.BlocksForAtLeast(time.Minute) },
+			func() {},
+			"Function call did not block for at least 1m0s.\nActual: returned after "},
+		{"CompletesWithin Goexit",
+			func(call AssertableCall) ValueAssertionResult { return call.CompletesWithin(time.Minute) },
+			func() { (&failNowRecorder{TB: t}).FailNow() },
+			"Function call exited via runtime.Goexit instead of returning.\nActual: exited after "},
+		{"BlocksForAtLeast Goexit",
+			func(call AssertableCall) ValueAssertionResult { return call.BlocksForAtLeast(time.Minute) },
+			func() { (&failNowRecorder{TB: t}).FailNow() },
+			"Function call exited via runtime.Goexit instead of blocking for at least 1m0s.\nActual: exited after "},
+	}
+
+	for _, c := range cases {
+		recorder := &logRecorder{TB: t}
+		For(t, c.id).ThatActual(c.assert(For(recorder).ThatCalling(c.call)).Passed()).IsFalse()
+		if For(t, c.id).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+			For(t, c.id).ThatActual(strings.HasPrefix(recorder.logs[0], c.expected)).IsTrue()
+		}
+	}
+}
+
+func blockUntilClosed(channel chan struct{}) {
+	<-channel
+}
+
+func TestPanicFailuresReportTheStack(t *testing.T) {
+	panicsWithError := func() { panicWith(fs.ErrPermission) }
+	cases := []struct {
+		id       string
+		call     func()
+		assert   func(call AssertableCall) PanicAssertionResult
+		expected string
+	}{
+		{"DoesNotPanic", panicsWithError,
+			func(call AssertableCall) PanicAssertionResult { return call.DoesNotPanic() },
+			"Function call panicked unexpectedly.\nPanic: permission denied\n"},
+		{"PanicsReporting", panicsWithError,
+			func(call AssertableCall) PanicAssertionResult { return call.PanicsReporting("expected") },
+			"Panic message mismatch.\nActual: permission denied\nExpected: expected\n"},
+		{"PanicsWithErrorMatching", panicsWithError,
+			func(call AssertableCall) PanicAssertionResult { return call.PanicsWithErrorMatching("^not found$") },
+			"Panic message mismatch.\nActual: permission denied\nExpected to match: ^not found$\n"},
+		{"PanicsWithValueOfType", panicsWithError,
+			func(call AssertableCall) PanicAssertionResult { return call.PanicsWithValueOfType(reflect.TypeOf("")) },
+			"Panic value type mismatch.\nActual: *errors.errorString (permission denied)\nExpected: string\n"},
+		{"PanicsWithErrorIs", panicsWithError,
+			func(call AssertableCall) PanicAssertionResult { return call.PanicsWithErrorIs(fs.ErrNotExist) },
+			"Panic error chain mismatch.\nActual chain:\n  \"permission denied\" (*errors.errorString)\n" +
+				"Expected to find: \"file does not exist\" (*errors.errorString)\n"},
+		{"PanicsWithErrorIs with a non-error value", func() { panicWith("denied") },
+			func(call AssertableCall) PanicAssertionResult { return call.PanicsWithErrorIs(fs.ErrNotExist) },
+			"Panic value is not an error.\nActual: denied (string)\n" +
+				"Expected: \"file does not exist\" (*errors.errorString)\n"},
+	}
+
+	for _, c := range cases {
+		recorder := &logRecorder{TB: t}
+		result := c.assert(For(recorder).ThatCalling(c.call))
+		For(t, c.id).ThatActual(result.Passed()).IsFalse()
+		if For(t, c.id).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+			message := recorder.logs[0]
+			For(t, c.id).ThatActual(strings.HasPrefix(message, c.expected+"Stack:\ngoroutine ")).IsTrue()
+			For(t, c.id).ThatActual(strings.Contains(message, "\npanic(")).IsTrue()
+			For(t, c.id).ThatActual(strings.Contains(message, "assert.panicWith(")).IsTrue()
+			For(t, c.id).ThatActual(strings.Contains(message, "debug.Stack")).IsFalse()
+		}
+	}
+}
+
+func panicWith(value interface{}) {
+	panic(value)
 }
@@ -0,0 +1,165 @@
+package assert
+
+import (
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// The file descriptors of the standard streams of the process, which are
+// redirected to capture what calls write to said streams.
+const (
+	stdoutDescriptor = 1
+	stderrDescriptor = 2
+)
+
+var (
+	// captureLock serializes output captures since the captured streams are
+	// global to the process.
+	captureLock sync.Mutex
+
+	// uncapturedStdout is the stdout to print failures to while the stdout
+	// of a call is captured, so that they don't get mixed into the captured
+	// output; it's nil otherwise. It's guarded by printLock.
+	uncapturedStdout *os.File
+
+	// heldLogs log the messages that tests logged while the stdout of a call
+	// was captured, since tests that run with the -v flag print logs to the
+	// stdout of the process as they're made; they're guarded by printLock.
+	heldLogs []func()
+)
+
+func (callable *assertableCall) WritesToStdout() AssertableString {
+	callable.testContext.Helper()
+	return callable.captureOutput(func(writer *os.File) (restore func(), err error) {
+		printLock.Lock()
+		defer printLock.Unlock()
+		original, restoreStream, err := redirectStream(stdoutDescriptor, &os.Stdout, writer)
+		if err != nil {
+			return nil, err
+		}
+		uncapturedStdout = original
+		return func() {
+			printLock.Lock()
+			defer printLock.Unlock()
+			uncapturedStdout = nil
+			restoreStream()
+		}, nil
+	})
+}
+
+func (callable *assertableCall) WritesToStderr() AssertableString {
+	callable.testContext.Helper()
+	return callable.captureOutput(func(writer *os.File) (restore func(), err error) {
+		_, restore, err = redirectStream(stderrDescriptor, &os.Stderr, writer)
+		return restore, err
+	})
+}
+
+func (callable *assertableCall) LogsMatching(pattern string) AssertableString {
+	callable.testContext.Helper()
+	logged := callable.captureOutput(func(writer *os.File) (restore func(), err error) {
+		_, restoreStream, err := redirectStream(stderrDescriptor, &os.Stderr, writer)
+		if err != nil {
+			return nil, err
+		}
+		original := log.Writer()
+		log.SetOutput(writer) // in case the standard logger doesn't write to stderr
+		return func() {
+			log.SetOutput(original)
+			restoreStream()
+		}, nil
+	})
+	logged.Matches(pattern)
+	return logged
+}
+
+// captureOutput redirects a stream to a pipe using the specified function,
+// which returns a function that restores the stream, makes the call, and
+// returns what was written to the stream, including by goroutines and child
+// processes, while the call ran. The logs that tests made meanwhile are
+// logged after the stream is restored, which it is even if the call exits
+// via runtime.Goexit; e.g., via t.FailNow.
+func (callable *assertableCall) captureOutput(redirect func(*os.File) (restore func(), err error)) AssertableString {
+	callable.testContext.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		callable.testContext.decoratedErrorf("Output not captured.\nError: %v\n", err)
+		return callable.testContext.ThatActualString("")
+	}
+
+	captured := make(chan string)
+	go func() { // drains the pipe so that writers never block on a full one
+		defer reader.Close()
+		var builder strings.Builder
+		io.Copy(&builder, reader)
+		captured <- builder.String()
+	}()
+
+	captureLock.Lock()
+	restore, err := redirect(writer)
+	if err != nil {
+		captureLock.Unlock()
+		writer.Close()
+		<-captured
+		callable.testContext.decoratedErrorf("Output not captured.\nError: %v\n", err)
+		return callable.testContext.ThatActualString("")
+	}
+	output, outcome := callable.callWhileCapturing(restore, writer, captured)
+	if outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call panicked unexpectedly.\nPanic: %v\nOutput: %q\n%s",
+			outcome.recovered, output, outcome.formatStack())
+	}
+	return callable.testContext.ThatActualString(output)
+}
+
+// callWhileCapturing makes the call while its output is captured by the
+// specified writer, and then restores the stream, closes the writer, and
+// returns the output that it captured. If the call exits via runtime.Goexit,
+// it still does so, and reports the exit, as the goroutine exits.
+func (callable *assertableCall) callWhileCapturing(restore func(), writer *os.File, captured <-chan string) (
+	output string, outcome *callOutcome) {
+
+	callable.testContext.Helper()
+	returned := false
+	defer func() {
+		callable.testContext.Helper()
+		restore()
+		captureLock.Unlock()
+		writer.Close()
+		output = <-captured // once child processes that inherited the stream close it too
+		for _, log := range takeHeldLogs() {
+			log()
+		}
+		if !returned {
+			callable.testContext.decoratedErrorf("Function call exited via runtime.Goexit instead of returning.\n"+
+				"Actual: exited (e.g., via t.FailNow or t.SkipNow)\nOutput: %q\n", output)
+		}
+	}()
+	outcome = recoverCall(callable.call)
+	returned = true
+	return "", outcome // the output is set once the stream is restored
+}
+
+// holdLogWhileStdoutIsCaptured holds the specified function, which logs
+// a message via a test, to be called once the stdout of a call is no longer
+// captured, and returns true if it is captured.
+func holdLogWhileStdoutIsCaptured(log func()) bool {
+	printLock.Lock()
+	defer printLock.Unlock()
+	if uncapturedStdout == nil {
+		return false
+	}
+	heldLogs = append(heldLogs, log)
+	return true
+}
+
+func takeHeldLogs() []func() {
+	printLock.Lock()
+	defer printLock.Unlock()
+	logs := heldLogs
+	heldLogs = nil
+	return logs
+}
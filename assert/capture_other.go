@@ -0,0 +1,16 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package assert
+
+import "os"
+
+// redirectStream redirects the specified variable that refers to a standard
+// stream (e.g., os.Stdout) to the specified writer; writers that were created
+// before, cgo code, and child processes write to the original stream since
+// its file descriptor isn't redirected on this platform. It returns the file
+// to write to the original stream, and a function that restores the stream.
+func redirectStream(descriptor int, stream **os.File, writer *os.File) (
+	original *os.File, restore func(), err error) {
+	original, *stream = *stream, writer
+	return original, func() { *stream = original }, nil
+}
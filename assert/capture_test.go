@@ -0,0 +1,176 @@
+package assert
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func ExampleAssertableCall_WritesToStdout_pass() {
+	greet := func(name string) { fmt.Printf("Hello, %s!\n", name) }
+	if For(t).ThatCalling(func() { greet("Ann") }).WritesToStdout().Equals("Hello, Ann!\n").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_WritesToStdout_fail() {
+	mockTestContextToAssert().ThatCalling(func() {
+		fmt.Print("captured")
+		mockTestContextToAssert("nested").ThatActual(42).Equals(13)
+	}).WritesToStdout().Equals("expected")
+	// Output:
+	// file:3: [nested] Value mismatch.
+	// Actual: 42
+	// Expected: 13
+	// file:3: String mismatch.
+	// Actual: "captured"
+	// Expected: "expected"
+}
+
+func ExampleAssertableCall_WritesToStderr_pass() {
+	warn := func() { fmt.Fprintln(os.Stderr, "deprecated flag: -v") }
+	if For(t).ThatCalling(warn).WritesToStderr().Equals("deprecated flag: -v\n").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_WritesToStderr_fail() {
+	mockTestContextToAssert().ThatCalling(func() { fmt.Println("not stderr") }).WritesToStderr().IsNotEmpty()
+	// Output:
+	// not stderr
+	// file:3: String is empty.
+}
+
+func ExampleAssertableCall_LogsMatching_pass() {
+	connect := func() { log.Print("connecting to db.example.com:5432") }
+	if For(t).ThatCalling(connect).LogsMatching(`connecting to \S+:\d+\n$`).IsNotEmpty().Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_LogsMatching_fail() {
+	defer func(flags int) { log.SetFlags(flags) }(log.Flags())
+	log.SetFlags(0)
+	mockTestContextToAssert("mismatch").ThatCalling(func() { log.Print("connected") }).LogsMatching("^connecting")
+	mockTestContextToAssert("invalid pattern").ThatCalling(func() {}).LogsMatching("connect(ing")
+	// Output:
+	// file:3: [mismatch] String mismatch.
+	// Actual: "connected\n"
+	// Expected: a string matching regex "^connecting"
+	// file:3: [invalid pattern] Invalid regular expression.
+	// Error: error parsing regexp: missing closing ): `connect(ing`
+}
+
+func TestOutputOfGoroutinesIsCaptured(t *testing.T) {
+	For(t).ThatCalling(func() {
+		var group sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			group.Add(1)
+			go func() {
+				defer group.Done()
+				fmt.Fprint(os.Stderr, "x")
+			}()
+		}
+		group.Wait()
+	}).WritesToStderr().Equals("xxx")
+}
+
+func TestLargeOutputIsCaptured(t *testing.T) {
+	line := strings.Repeat("x", 1023) + "\n"
+	For(t).ThatCalling(func() {
+		for i := 0; i < 1024; i++ {
+			fmt.Print(line) // exceeds the buffer of the pipe
+		}
+	}).WritesToStdout().Equals(strings.Repeat(line, 1024))
+}
+
+func TestCapturedStreamsAreRestored(t *testing.T) {
+	stdout, stderr, logOutput := os.Stdout, os.Stderr, log.Writer()
+	For(t).ThatCalling(func() {}).WritesToStdout().IsEmpty()
+	For(t).ThatCalling(func() {}).WritesToStderr().IsEmpty()
+	For(t).ThatCalling(func() {}).LogsMatching("^$")
+	For(t).ThatActual(os.Stdout).Equals(stdout)
+	For(t).ThatActual(os.Stderr).Equals(stderr)
+	For(t).ThatActual(log.Writer()).Equals(logOutput)
+	For(t).ThatActual(uncapturedStdout == nil).IsTrue()
+}
+
+func TestOutputOfWritersCreatedBeforeTheCallIsCaptured(t *testing.T) {
+	stdout, stderr := os.Stdout, os.Stderr
+	logger := log.New(os.Stderr, "", 0)
+	For(t).ThatCalling(func() { fmt.Fprint(stdout, "saved") }).WritesToStdout().Equals("saved")
+	For(t).ThatCalling(func() { fmt.Fprint(stderr, "saved") }).WritesToStderr().Equals("saved")
+	For(t).ThatCalling(func() { logger.Print("cached") }).LogsMatching("^cached\n$")
+}
+
+func TestTestLogsAreHeldWhileStdoutIsCaptured(t *testing.T) {
+	recorder := &logRecorder{TB: t}
+	logsDuringCall := 0
+	For(t).ThatCalling(func() {
+		For(recorder).ThatActual(42).Equals(13)
+		logsDuringCall = len(recorder.logs)
+	}).WritesToStdout().IsEmpty()
+
+	For(t).ThatActual(logsDuringCall).Equals(0)
+	For(t).ThatActual(recorder.logs).Equals([]string{"Value mismatch.\nActual: 42\nExpected: 13"})
+}
+
+func TestCapturingOutputOfPanickingCalls(t *testing.T) {
+	stdout := os.Stdout
+	recorder := &logRecorder{TB: t}
+	captured := For(recorder).ThatCalling(func() {
+		fmt.Print("starting")
+		panic("boom")
+	}).WritesToStdout()
+
+	For(t).ThatActual(os.Stdout).Equals(stdout)
+	For(t).ThatActual(captured.Equals("starting").Passed()).IsTrue()
+	if For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		For(t).ThatActual(strings.HasPrefix(recorder.logs[0], "Function call panicked unexpectedly.\nPanic: boom\n"+
+			"Output: \"starting\"\nStack:\ngoroutine ")).IsTrue()
+	}
+}
+
+func TestCapturingOutputOfCallsThatExitTheGoroutine(t *testing.T) {
+	stdout, stderr, logOutput := os.Stdout, os.Stderr, log.Writer()
+	cases := []struct {
+		stream  string
+		write   func()
+		capture func(AssertableCall) AssertableString
+	}{
+		{"stdout", func() { fmt.Print("starting") }, AssertableCall.WritesToStdout},
+		{"stderr", func() { fmt.Fprint(os.Stderr, "starting") }, AssertableCall.WritesToStderr},
+		{"logs", func() { log.New(log.Writer(), "", 0).Print("starting") },
+			func(call AssertableCall) AssertableString { return call.LogsMatching("") }},
+	}
+	for _, c := range cases {
+		stream, recorder, isReached := c.stream, &logRecorder{}, false
+		t.Run(stream, func(t *testing.T) {
+			recorder.TB = t
+			c.capture(For(recorder).ThatCalling(func() {
+				c.write()
+				t.SkipNow()
+			}))
+			isReached = true
+		})
+
+		For(t, stream).ThatActual(isReached).IsFalse()
+		For(t, stream).ThatActual(recorder.failed).IsTrue()
+		if For(t, stream).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+			For(t, stream).ThatActualString(recorder.logs[0]).HasPrefix(
+				"Function call exited via runtime.Goexit instead of returning.\n" +
+					"Actual: exited (e.g., via t.FailNow or t.SkipNow)\nOutput: \"starting")
+		}
+		For(t, stream).ThatActual(os.Stdout).Equals(stdout)
+		For(t, stream).ThatActual(os.Stderr).Equals(stderr)
+		For(t, stream).ThatActual(log.Writer()).Equals(logOutput)
+		For(t, stream).ThatActual(uncapturedStdout == nil).IsTrue()
+	}
+	For(t).ThatCalling(func() { fmt.Print("next") }).WritesToStdout().Equals("next") // i.e., not deadlocked
+}
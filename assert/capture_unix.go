@@ -0,0 +1,40 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package assert
+
+import (
+	"os"
+	"syscall"
+)
+
+// redirectStream redirects the standard stream with the specified file
+// descriptor, and the specified variable that refers to the stream (e.g.,
+// os.Stdout), to the specified writer, so that whatever writes to the stream
+// does, including writers that were created before, cgo code, and child
+// processes. It returns the file to write to the original stream, which
+// mustn't be used after the stream is restored, and a function that restores
+// the stream.
+func redirectStream(descriptor int, stream **os.File, writer *os.File) (
+	original *os.File, restore func(), err error) {
+	savedDescriptor, err := syscall.Dup(descriptor)
+	if err != nil {
+		return nil, nil, err
+	}
+	syscall.CloseOnExec(savedDescriptor)
+	if err := dup2(int(writer.Fd()), descriptor); err != nil {
+		syscall.Close(savedDescriptor)
+		return nil, nil, err
+	}
+
+	saved := os.NewFile(uintptr(savedDescriptor), (*stream).Name())
+	variable := *stream
+	*stream, original = writer, variable // e.g., examples redirect os.Stdout to pipes of their own
+	if variable.Fd() == uintptr(descriptor) {
+		original = saved
+	}
+	return original, func() {
+		*stream = variable
+		dup2(savedDescriptor, descriptor)
+		saved.Close()
+	}, nil
+}
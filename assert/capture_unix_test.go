@@ -0,0 +1,28 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package assert
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+)
+
+func TestOutputWrittenToFileDescriptorsIsCaptured(t *testing.T) {
+	For(t).ThatCalling(func() { syscall.Write(stdoutDescriptor, []byte("fd 1")) }).WritesToStdout().Equals("fd 1")
+	For(t).ThatCalling(func() { syscall.Write(stderrDescriptor, []byte("fd 2")) }).WritesToStderr().Equals("fd 2")
+}
+
+func TestOutputOfChildProcessesIsCaptured(t *testing.T) {
+	stdout, stderr := os.Stdout, os.Stderr // as cached before the call
+	run := func(script string) func() {
+		return func() {
+			command := exec.Command("sh", "-c", script)
+			command.Stdout, command.Stderr = stdout, stderr
+			For(t).ThatActualError(command.Run()).IsNil()
+		}
+	}
+	For(t).ThatCalling(run("echo out")).WritesToStdout().Equals("out\n")
+	For(t).ThatCalling(run("echo err >&2")).WritesToStderr().Equals("err\n")
+}
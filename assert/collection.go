@@ -0,0 +1,250 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AssertableCollection represents an under-test collection that's expected
+// to meet certain criteria. Elements are compared using reflect.DeepEqual.
+type AssertableCollection interface {
+	// HasLength asserts that the specified actual collection has the expected
+	// number of elements.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasLength(expected int) ValueAssertionResult
+
+	// Contains asserts that the specified actual collection contains
+	// the expected element.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Contains(element interface{}) ValueAssertionResult
+
+	// ContainsAll asserts that the specified actual collection contains
+	// all the expected elements, in any order, among other elements (if any).
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsAll(elements ...interface{}) ValueAssertionResult
+
+	// ContainsExactlyInAnyOrder asserts that the specified actual collection
+	// contains the expected elements, and nothing else, in any order.
+	// Duplicate elements must appear as many times as they are expected.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsExactlyInAnyOrder(elements ...interface{}) ValueAssertionResult
+
+	// ContainsInOrder asserts that the expected elements appear in
+	// the specified actual collection in the given order; other elements
+	// may appear in between.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsInOrder(elements ...interface{}) ValueAssertionResult
+
+	// IsSubsetOf asserts that every element in the specified actual collection
+	// is one of the specified elements.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsSubsetOf(elements ...interface{}) ValueAssertionResult
+
+	// IsEmpty asserts that the specified actual collection has no elements.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsEmpty() ValueAssertionResult
+}
+
+type assertableCollection struct {
+	testContext *testContext
+	value       interface{}
+	elements    []interface{}
+	isSupported bool
+}
+
+func newAssertableCollection(testContext *testContext, value interface{}) *assertableCollection {
+	elements, isSupported := collectionElements(value)
+	return &assertableCollection{testContext: testContext, value: value, elements: elements, isSupported: isSupported}
+}
+
+func (actual *assertableCollection) HasLength(expected int) ValueAssertionResult {
+	actual.testContext.Helper()
+	if !actual.isSupported {
+		return actual.unsupportedCollectionResult(expected)
+	}
+	hasLength := len(actual.elements) == expected
+	if !hasLength {
+		actual.testContext.decoratedErrorf(
+			"Collection length mismatch.\nActual: %d\nExpected: %d\nElements: %s\n",
+			len(actual.elements), expected, formatElements(actual.elements))
+	}
+	return actual.testContext.resultOf(hasLength, actual.value, expected)
+}
+
+func (actual *assertableCollection) Contains(element interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.ContainsAll(element)
+}
+
+func (actual *assertableCollection) ContainsAll(elements ...interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	if !actual.isSupported {
+		return actual.unsupportedCollectionResult(elements)
+	}
+	missing, _ := subtractElements(elements, actual.elements)
+	if len(missing) > 0 {
+		actual.testContext.decoratedErrorf(
+			"Collection is missing elements.\nMissing: %s\nActual: %s\n",
+			formatElements(missing), formatElements(actual.elements))
+	}
+	return actual.testContext.resultOf(len(missing) == 0, actual.value, elements)
+}
+
+func (actual *assertableCollection) ContainsExactlyInAnyOrder(elements ...interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	if !actual.isSupported {
+		return actual.unsupportedCollectionResult(elements)
+	}
+	missing, unexpected := subtractElements(elements, actual.elements)
+	passed := len(missing) == 0 && len(unexpected) == 0
+	if !passed {
+		actual.testContext.decoratedErrorf(
+			"Collection elements mismatch.\nMissing: %s\nUnexpected: %s\n",
+			formatElements(missing), formatElements(unexpected))
+	}
+	return actual.testContext.resultOf(passed, actual.value, elements)
+}
+
+func (actual *assertableCollection) ContainsInOrder(elements ...interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	if !actual.isSupported {
+		return actual.unsupportedCollectionResult(elements)
+	}
+	next := 0
+	for _, element := range actual.elements {
+		if next < len(elements) && reflect.DeepEqual(element, elements[next]) {
+			next++
+		}
+	}
+	passed := next == len(elements)
+	if !passed {
+		actual.testContext.decoratedErrorf(
+			"Collection elements are not in order.\nMissing in order: %#v (index %d of expected)\nActual: %s\n",
+			elements[next], next, formatElements(actual.elements))
+	}
+	return actual.testContext.resultOf(passed, actual.value, elements)
+}
+
+func (actual *assertableCollection) IsSubsetOf(elements ...interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	if !actual.isSupported {
+		return actual.unsupportedCollectionResult(elements)
+	}
+	unexpected := []interface{}{}
+	for _, element := range actual.elements {
+		if indexOfElement(elements, element) < 0 {
+			unexpected = append(unexpected, element)
+		}
+	}
+	if len(unexpected) > 0 {
+		actual.testContext.decoratedErrorf(
+			"Collection is not a subset.\nUnexpected: %s\nSuperset: %s\n",
+			formatElements(unexpected), formatElements(elements))
+	}
+	return actual.testContext.resultOf(len(unexpected) == 0, actual.value, elements)
+}
+
+func (actual *assertableCollection) IsEmpty() ValueAssertionResult {
+	actual.testContext.Helper()
+	if !actual.isSupported {
+		return actual.unsupportedCollectionResult("<empty collection>")
+	}
+	isEmpty := len(actual.elements) == 0
+	if !isEmpty {
+		actual.testContext.decoratedErrorf("Collection is not empty.\nActual: %s\n", formatElements(actual.elements))
+	}
+	return actual.testContext.resultOf(isEmpty, actual.value, "<empty collection>")
+}
+
+func (actual *assertableCollection) unsupportedCollectionResult(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	actual.testContext.decoratedErrorf(
+		"Unsupported collection type.\nActual: %T=%v\nExpected: slice, array, channel, or iter.Seq\n",
+		actual.value, actual.value)
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+// collectionElements returns the elements of the specified slice, array,
+// channel, or iter.Seq. A channel is read until it's closed or until
+// receiving from it would block; i.e., it's expected to have been drained by
+// the code under test (otherwise, whatever is left is consumed).
+func collectionElements(collection interface{}) (elements []interface{}, isSupported bool) {
+	value := reflect.ValueOf(collection)
+	elements = []interface{}{}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			elements = append(elements, value.Index(i).Interface())
+		}
+	case reflect.Chan:
+		if value.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, false
+		}
+		for !value.IsNil() {
+			element, ok := value.TryRecv()
+			if !ok {
+				break
+			}
+			elements = append(elements, element.Interface())
+		}
+	case reflect.Func:
+		if !isIteratorSequence(value.Type()) {
+			return nil, false
+		}
+		if !value.IsNil() {
+			yield := reflect.MakeFunc(value.Type().In(0), func(args []reflect.Value) []reflect.Value {
+				elements = append(elements, args[0].Interface())
+				return []reflect.Value{reflect.ValueOf(true)}
+			})
+			value.Call([]reflect.Value{yield})
+		}
+	default:
+		return nil, false
+	}
+	return elements, true
+}
+
+// isIteratorSequence returns true if the specified type has the signature of
+// iter.Seq; i.e., func(yield func(V) bool).
+func isIteratorSequence(t reflect.Type) bool {
+	if t.NumIn() != 1 || t.NumOut() != 0 {
+		return false
+	}
+	yield := t.In(0)
+	return yield.Kind() == reflect.Func && yield.NumIn() == 1 &&
+		yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool
+}
+
+// subtractElements matches the expected elements against the actual ones,
+// respecting duplicates, and returns whatever is left unmatched on each side.
+func subtractElements(expected, actual []interface{}) (missing, unexpected []interface{}) {
+	unexpected = append([]interface{}{}, actual...)
+	missing = []interface{}{}
+	for _, element := range expected {
+		if i := indexOfElement(unexpected, element); i >= 0 {
+			unexpected = append(unexpected[:i], unexpected[i+1:]...)
+		} else {
+			missing = append(missing, element)
+		}
+	}
+	return missing, unexpected
+}
+
+func indexOfElement(elements []interface{}, element interface{}) int {
+	for i, candidate := range elements {
+		if reflect.DeepEqual(candidate, element) {
+			return i
+		}
+	}
+	return -1
+}
+
+func formatElements(elements []interface{}) string {
+	formatted := make([]string, len(elements))
+	for i, element := range elements {
+		formatted[i] = fmt.Sprintf("%#v", element)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
@@ -0,0 +1,210 @@
+package assert
+
+import "fmt"
+
+func ExampleAssertableCollection_HasLength_pass() {
+	channel := make(chan int, 2)
+	channel <- 4
+	channel <- 2
+	close(channel)
+	sequence := func(yield func(int) bool) {
+		for _, i := range []int{4, 2} {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	cases := []struct {
+		id         string
+		collection interface{}
+	}{
+		{"slice", []int{4, 2}},
+		{"array", [...]string{"4", "2"}},
+		{"channel", channel},
+		{"iter.Seq", sequence},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualCollection(c.collection).HasLength(2).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: slice
+	// Passed: array
+	// Passed: channel
+	// Passed: iter.Seq
+}
+
+func ExampleAssertableCollection_HasLength_fail() {
+	if !mockTestContextToAssert().ThatActualCollection([]int{42}).HasLength(2).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Collection length mismatch.
+	// Actual: 1
+	// Expected: 2
+	// Elements: [42]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_HasLength_unsupportedType() {
+	if !mockTestContextToAssert().ThatActualCollection(42).HasLength(1).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Unsupported collection type.
+	// Actual: int=42
+	// Expected: slice, array, channel, or iter.Seq
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_Contains_pass() {
+	if For(t).ThatActualCollection([]string{"foo", "bar"}).Contains("bar").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCollection_Contains_fail() {
+	if !mockTestContextToAssert().ThatActualCollection([]string{"foo"}).Contains("bar").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Collection is missing elements.
+	// Missing: ["bar"]
+	// Actual: ["foo"]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_ContainsAll_pass() {
+	if For(t).ThatActualCollection([]int{1, 2, 3}).ContainsAll(3, 1).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCollection_ContainsAll_fail() {
+	if !mockTestContextToAssert().ThatActualCollection([]int{1, 2, 3}).ContainsAll(3, 4, 5).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Collection is missing elements.
+	// Missing: [4, 5]
+	// Actual: [1, 2, 3]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_ContainsExactlyInAnyOrder_pass() {
+	if For(t).ThatActualCollection([]int{1, 2, 2}).ContainsExactlyInAnyOrder(2, 1, 2).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCollection_ContainsExactlyInAnyOrder_fail() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected []interface{}
+	}{
+		{"missing and unexpected", []int{1, 2}, []interface{}{2, 3}},
+		{"duplicates", []int{1, 1}, []interface{}{1}},
+	}
+
+	for _, c := range cases {
+		collection := mockTestContextToAssert(c.id).ThatActualCollection(c.actual)
+		if !collection.ContainsExactlyInAnyOrder(c.expected...).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [missing and unexpected] Collection elements mismatch.
+	// Missing: [3]
+	// Unexpected: [1]
+	// Assertion failed successfully!
+	// file:3: [duplicates] Collection elements mismatch.
+	// Missing: []
+	// Unexpected: [1]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_ContainsInOrder_pass() {
+	if For(t).ThatActualCollection([]int{1, 2, 3, 4}).ContainsInOrder(1, 3, 4).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCollection_ContainsInOrder_fail() {
+	if !mockTestContextToAssert().ThatActualCollection([]int{1, 2, 3}).ContainsInOrder(1, 3, 2).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Collection elements are not in order.
+	// Missing in order: 2 (index 2 of expected)
+	// Actual: [1, 2, 3]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_IsSubsetOf_pass() {
+	if For(t).ThatActualCollection([]string{"b", "a", "b"}).IsSubsetOf("a", "b", "c").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCollection_IsSubsetOf_fail() {
+	if !mockTestContextToAssert().ThatActualCollection([]string{"a", "d"}).IsSubsetOf("a", "b").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Collection is not a subset.
+	// Unexpected: ["d"]
+	// Superset: ["a", "b"]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableCollection_IsEmpty_pass() {
+	var nilSlice []int
+	var emptySequence func(func(string) bool)
+	cases := []struct {
+		id         string
+		collection interface{}
+	}{
+		{"nil slice", nilSlice},
+		{"empty array", [0]int{}},
+		{"closed channel", closedChannel()},
+		{"nil iter.Seq", emptySequence},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualCollection(c.collection).IsEmpty().Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: nil slice
+	// Passed: empty array
+	// Passed: closed channel
+	// Passed: nil iter.Seq
+}
+
+func ExampleAssertableCollection_IsEmpty_fail() {
+	if !mockTestContextToAssert().ThatActualCollection([]int{42}).IsEmpty().ThenDiffOnFail().Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Collection is not empty.
+	// Actual: [42]
+	// Diff:
+	// Actual: []int=[42] != string=<empty collection>
+	// Assertion failed successfully!
+}
+
+func closedChannel() chan int {
+	channel := make(chan int)
+	close(channel)
+	return channel
+}
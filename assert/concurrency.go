@@ -0,0 +1,187 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxReportedConcurrencyFailures caps the failures that are reported in full
+// since a racy call tends to fail the same way in many goroutines.
+const maxReportedConcurrencyFailures = 10
+
+var (
+	// stressWorkersLock guards stressWorkers, which maps the IDs of the
+	// goroutines that stress calls to the workers that they run.
+	stressWorkersLock sync.RWMutex
+	stressWorkers     = map[int]*stressWorker{}
+)
+
+// stressWorker runs a call repeatedly in a goroutine during a round of a
+// concurrency stress test.
+type stressWorker struct {
+	round  *stressRound
+	index  int // -1 for the invariants that are checked after the round
+	report *stressReport
+}
+
+type stressRound struct {
+	number, procs int
+}
+
+// stressReport collects the failures of a concurrency stress test.
+type stressReport struct {
+	lock     sync.Mutex
+	failures []*stressFailure
+}
+
+type stressFailure struct {
+	round  *stressRound
+	worker int
+	*SoftAssertionFailure
+}
+
+func (callable *assertableCall) IsSafeUnderConcurrency(goroutines, iterations int,
+	invariants ...func(TestContext)) ValueAssertionResult {
+	callable.testContext.Helper()
+	if goroutines < 1 || iterations < 1 {
+		callable.testContext.decoratedErrorf("Invalid number of goroutines or iterations.\n"+
+			"Actual: %d goroutines, %d iterations\nExpected: at least 1 of each\n", goroutines, iterations)
+		return callable.testContext.resultOf(false, goroutines*iterations, 1)
+	}
+
+	report := &stressReport{}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for i, procs := range stressRoundProcs() {
+		round := &stressRound{number: i + 1, procs: procs}
+		runtime.GOMAXPROCS(procs)
+		callable.stress(round, goroutines, iterations, report)
+		for _, invariant := range invariants {
+			(&stressWorker{round: round, index: -1, report: report}).run(func() { invariant(callable.testContext) })
+		}
+	}
+
+	failures := report.sortedFailures()
+	if len(failures) > 0 {
+		callable.testContext.decoratedErrorf("%s", formatStressFailures(failures, goroutines, iterations))
+	}
+	return callable.testContext.resultOf(len(failures) == 0, len(failures), 0)
+}
+
+// stressRoundProcs returns the GOMAXPROCS of each round of a stress test;
+// rounds run without parallelism, with little, and with oversubscription.
+func stressRoundProcs() []int {
+	procs, isIncluded := []int{}, map[int]bool{}
+	for _, n := range []int{1, 2, runtime.NumCPU(), 2 * runtime.NumCPU()} {
+		if !isIncluded[n] {
+			procs, isIncluded[n] = append(procs, n), true
+		}
+	}
+	return procs
+}
+
+// stress runs the call the specified number of iterations in each of the
+// specified number of goroutines, which start together once they all have
+// been started; a goroutine stops iterating once the call panics.
+func (callable *assertableCall) stress(round *stressRound, goroutines, iterations int, report *stressReport) {
+	var ready, done sync.WaitGroup
+	start := make(chan struct{})
+	ready.Add(goroutines)
+	done.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		worker := &stressWorker{round: round, index: i, report: report}
+		go func() {
+			defer done.Done()
+			ready.Done()
+			<-start
+			worker.run(func() {
+				for j := 0; j < iterations; j++ {
+					callable.call()
+				}
+			})
+		}()
+	}
+	ready.Wait()
+	close(start)
+	done.Wait()
+}
+
+// run runs the specified function in the current goroutine and collects the
+// failures of the assertions that it makes, as well as its panic, if any.
+func (worker *stressWorker) run(function func()) {
+	id := currentGoroutineID()
+	stressWorkersLock.Lock()
+	stressWorkers[id] = worker
+	stressWorkersLock.Unlock()
+	defer func() {
+		stressWorkersLock.Lock()
+		delete(stressWorkers, id)
+		stressWorkersLock.Unlock()
+	}()
+
+	if outcome := recoverCall(function); outcome.hasPanicked {
+		worker.add(&SoftAssertionFailure{Line: noCallerInfoLineNumber,
+			Message: fmt.Sprintf("Function call panicked.\nPanic: %v\n%s", outcome.recovered, outcome.formatStack())})
+	}
+}
+
+func (worker *stressWorker) add(failure *SoftAssertionFailure) {
+	worker.report.lock.Lock()
+	defer worker.report.lock.Unlock()
+	worker.report.failures = append(worker.report.failures,
+		&stressFailure{round: worker.round, worker: worker.index, SoftAssertionFailure: failure})
+}
+
+// currentStressWorker returns the worker that the calling goroutine runs, if
+// it's stressing a call; it returns nil otherwise.
+func currentStressWorker() *stressWorker {
+	stressWorkersLock.RLock()
+	defer stressWorkersLock.RUnlock()
+	if len(stressWorkers) == 0 { // avoids looking up the ID of the goroutine
+		return nil
+	}
+	return stressWorkers[currentGoroutineID()]
+}
+
+// sortedFailures returns the failures ordered by round, then by goroutine,
+// with the invariants' last, then by the order they failed in.
+func (report *stressReport) sortedFailures() []*stressFailure {
+	report.lock.Lock()
+	defer report.lock.Unlock()
+	failures := append([]*stressFailure{}, report.failures...)
+	sort.SliceStable(failures, func(i, j int) bool {
+		if a, b := failures[i], failures[j]; a.round.number != b.round.number {
+			return a.round.number < b.round.number
+		} else if (a.worker < 0) != (b.worker < 0) {
+			return b.worker < 0
+		} else {
+			return a.worker < b.worker
+		}
+	})
+	return failures
+}
+
+func formatStressFailures(failures []*stressFailure, goroutines, iterations int) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Function call is not safe under concurrency (%d %s).\n"+
+		"Goroutines: %d\nIterations: %d per goroutine per round\n", len(failures),
+		pluralize(len(failures), "failure"), goroutines, iterations)
+	for i, failure := range failures {
+		if i == maxReportedConcurrencyFailures {
+			remaining := len(failures) - i
+			fmt.Fprintf(&builder, "... and %d more %s\n", remaining, pluralize(remaining, "failure"))
+			break
+		}
+
+		fmt.Fprintf(&builder, "Round %d (GOMAXPROCS=%d), ", failure.round.number, failure.round.procs)
+		if failure.worker < 0 {
+			builder.WriteString("invariant: ")
+		} else {
+			fmt.Fprintf(&builder, "goroutine %d: ", failure.worker)
+		}
+		builder.WriteString(failure.SoftAssertionFailure.String())
+	}
+	return builder.String()
+}
@@ -0,0 +1,100 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func ExampleAssertableCall_IsSafeUnderConcurrency_pass() {
+	var lock sync.Mutex
+	counts := map[string]int{}
+	increment := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		counts["calls"]++
+	}
+
+	if For(t).ThatCalling(increment).IsSafeUnderConcurrency(8, 100, func(a TestContext) {
+		a.ThatActual(counts["calls"] % 800).Equals(0)
+	}).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_IsSafeUnderConcurrency_fail() {
+	mockTestContextToAssert().ThatCalling(func() {}).IsSafeUnderConcurrency(0, 100)
+	// Output:
+	// file:3: Invalid number of goroutines or iterations.
+	// Actual: 0 goroutines, 100 iterations
+	// Expected: at least 1 of each
+}
+
+func TestConcurrencyFailuresAreLabeledByRoundAndGoroutine(t *testing.T) {
+	recorder, line, calls := &logRecorder{TB: t}, 0, int64(0)
+	_, _, line, _ = runtime.Caller(0)
+	call := func() {
+		if atomic.AddInt64(&calls, 1) <= 2 { // only in the first round
+			For(recorder).ThatActual(1).Equals(2)
+		}
+	}
+	invariant := func(a TestContext) { a.ThatActual(calls).Equals(int64(2)) }
+	For(t).ThatActual(For(recorder).ThatCalling(call).IsSafeUnderConcurrency(2, 1, invariant).Passed()).IsFalse()
+
+	For(t).ThatActual(recorder.failed).IsTrue()
+	if !For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		return
+	}
+	failures := len(stressRoundProcs()) + 1 // the invariant fails after each round but the first
+	For(t).ThatActual(strings.HasPrefix(recorder.logs[0], fmt.Sprintf(
+		"Function call is not safe under concurrency (%d failures).\nGoroutines: 2\n"+
+			"Iterations: 1 per goroutine per round\n"+
+			"Round 1 (GOMAXPROCS=1), goroutine 0: concurrency_test.go:%[2]d: Value mismatch.\nActual: 1\nExpected: 2\n"+
+			"Round 1 (GOMAXPROCS=1), goroutine 1: concurrency_test.go:%[2]d: Value mismatch.\nActual: 1\nExpected: 2\n"+
+			"Round 2 (GOMAXPROCS=2), invariant: concurrency_test.go:%[3]d: Value mismatch.\nActual: 4\nExpected: 2",
+		failures, line+3, line+6))).IsTrue()
+	For(t).ThatActual(len(stressWorkers)).Equals(0)
+}
+
+func TestConcurrencyPanicsAreReportedWithTheirStacks(t *testing.T) {
+	recorder := &logRecorder{TB: t}
+	For(recorder).ThatCalling(func() { panic("boom") }).IsSafeUnderConcurrency(1, 100)
+	if For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		rounds := len(stressRoundProcs()) // a goroutine stops iterating once the call panics
+		message := recorder.logs[0]
+		For(t).ThatActual(strings.HasPrefix(message, fmt.Sprintf("Function call is not safe under concurrency "+
+			"(%d %s).\n", rounds, pluralize(rounds, "failure")))).IsTrue()
+		For(t).ThatActual(strings.Contains(message, "Round 1 (GOMAXPROCS=1), goroutine 0: Function call panicked.\n"+
+			"Panic: boom\nStack:\ngoroutine ")).IsTrue()
+	}
+}
+
+func TestConcurrencyRoundsVaryGOMAXPROCS(t *testing.T) {
+	var lock sync.Mutex
+	procs, original := map[int]bool{}, runtime.GOMAXPROCS(0)
+	For(t).ThatCalling(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		procs[runtime.GOMAXPROCS(0)] = true
+	}).IsSafeUnderConcurrency(4, 10)
+
+	For(t).ThatActual(runtime.GOMAXPROCS(0)).Equals(original)
+	for _, n := range stressRoundProcs() {
+		For(t, n).ThatActual(procs[n]).IsTrue()
+	}
+}
+
+func TestFormatStressFailuresCapsTheReportedFailures(t *testing.T) {
+	round, failures := &stressRound{number: 1, procs: 1}, []*stressFailure{}
+	for i := 0; i < maxReportedConcurrencyFailures+2; i++ {
+		failures = append(failures, &stressFailure{round: round, worker: i,
+			SoftAssertionFailure: &SoftAssertionFailure{Line: noCallerInfoLineNumber, Message: "Failed.\n"}})
+	}
+	formatted := formatStressFailures(failures, 12, 1)
+	For(t).ThatActual(strings.Count(formatted, "Failed.\n")).Equals(maxReportedConcurrencyFailures)
+	For(t).ThatActual(strings.HasSuffix(formatted, "goroutine 9: Failed.\n... and 2 more failures\n")).IsTrue()
+}
@@ -0,0 +1,326 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+const (
+	rootPath               = "Actual"
+	missingValue           = "(missing)"
+	maxReportedDifferences = 50
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// valueDiffer walks two values side by side, following reflect.DeepEqual
+// rules as adjusted by the equality options, and collects the leaves in which
+// the values differ.
+type valueDiffer struct {
+	options     *equalityOptions
+	differences []*valueDifference
+	visited     map[visit]bool
+}
+
+// visit identifies a pair of references that was already compared; it's used
+// to stop at cycles.
+type visit struct {
+	actual   uintptr
+	expected uintptr
+	length   int
+	reflect.Type
+}
+
+// valueDifference describes a leaf in which two values differ.
+type valueDifference struct {
+	path     string
+	actual   string
+	expected string
+}
+
+func diffValues(actual, expected interface{}, options *equalityOptions) []*valueDifference {
+	return diffValuesAt(rootPath, actual, expected, options)
+}
+
+// diffValuesAt diffs the specified values, labeling the differences with
+// paths relative to the specified one.
+func diffValuesAt(path string, actual, expected interface{}, options *equalityOptions) []*valueDifference {
+	differ := &valueDiffer{options: options, visited: map[visit]bool{}}
+	differ.diff(path, "", reflect.ValueOf(actual), reflect.ValueOf(expected))
+	return differ.differences
+}
+
+func (difference *valueDifference) String() string {
+	return difference.path + ": " + difference.actual + " != " + difference.expected
+}
+
+// summarizeDifferences returns a short summary of the specified differences
+// of the specified actual value followed by one line per difference;
+// for example:
+//     Value mismatch (2 differences in *mail.Address).
+//     Actual.Name: "Richard Hendricks" != "Erlich Bachman"
+//     Actual.Address: "richard@pp.io" != "erlich@pp.io"
+func summarizeDifferences(actual interface{}, differences []*valueDifference) string {
+	return fmt.Sprintf("Value mismatch (%d %s in %T).\n%s",
+		len(differences), pluralize(len(differences), "difference"), actual, formatDifferences(differences))
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+func formatDifferences(differences []*valueDifference) string {
+	var builder strings.Builder
+	for i, difference := range differences {
+		if i == maxReportedDifferences {
+			fmt.Fprintf(&builder, "... and %d more\n", len(differences)-i)
+			break
+		}
+		builder.WriteString(difference.String())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+func (differ *valueDiffer) diff(path, fieldPath string, actual, expected reflect.Value) {
+	actual, expected = accessible(actual), accessible(expected)
+	switch {
+	case !actual.IsValid() || !expected.IsValid():
+		if actual.IsValid() != expected.IsValid() {
+			differ.report(path, actual, expected)
+		}
+		return
+	case actual.Type() != expected.Type():
+		differ.differences = append(differ.differences, &valueDifference{
+			path: path, actual: formatTypedValue(actual), expected: formatTypedValue(expected)})
+		return
+	case differ.isVisited(actual, expected):
+		return
+	}
+
+	switch actual.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if actual.Kind() == reflect.Ptr && actual.Pointer() == expected.Pointer() {
+			return
+		}
+		if actual.IsNil() || expected.IsNil() {
+			if actual.IsNil() != expected.IsNil() {
+				differ.report(path, actual, expected)
+			}
+			return
+		}
+		differ.diff(path, fieldPath, actual.Elem(), expected.Elem())
+	case reflect.Struct:
+		differ.diffStructs(path, fieldPath, actual, expected)
+	case reflect.Slice, reflect.Array:
+		differ.diffSequences(path, fieldPath, actual, expected)
+	case reflect.Map:
+		differ.diffMaps(path, fieldPath, actual, expected)
+	case reflect.Float32, reflect.Float64:
+		a, b := actual.Float(), expected.Float()
+		if a != b && !(math.Abs(a-b) <= differ.options.floatTolerance) {
+			differ.report(path, actual, expected)
+		}
+	case reflect.Func:
+		if !actual.IsNil() || !expected.IsNil() { // like reflect.DeepEqual, functions are equal only if both are nil
+			differ.report(path, actual, expected)
+		}
+	default:
+		if actual.Interface() != expected.Interface() {
+			differ.report(path, actual, expected)
+		}
+	}
+}
+
+func (differ *valueDiffer) diffStructs(path, fieldPath string, actual, expected reflect.Value) {
+	if differ.options.equateTimes && actual.Type() == timeType {
+		if !actual.Interface().(time.Time).Equal(expected.Interface().(time.Time)) {
+			differ.report(path, actual, expected)
+		}
+		return
+	}
+
+	for i := 0; i < actual.NumField(); i++ {
+		field := actual.Type().Field(i)
+		nestedFieldPath := field.Name
+		if fieldPath != "" {
+			nestedFieldPath = fieldPath + "." + field.Name
+		}
+		if !differ.options.isIgnoredField(nestedFieldPath, field) {
+			differ.diff(path+"."+field.Name, nestedFieldPath, actual.Field(i), expected.Field(i))
+		}
+	}
+}
+
+func (differ *valueDiffer) diffSequences(path, fieldPath string, actual, expected reflect.Value) {
+	if actual.Kind() == reflect.Slice {
+		if isSameReference(actual, expected) {
+			return
+		}
+		if differ.isNilMismatch(actual, expected) {
+			differ.report(path, actual, expected)
+			return
+		}
+		actual, expected = differ.sorted(actual), differ.sorted(expected)
+	}
+
+	for i := 0; i < actual.Len() || i < expected.Len(); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= actual.Len():
+			differ.reportFormatted(elementPath, missingValue, formatValue(expected.Index(i)))
+		case i >= expected.Len():
+			differ.reportFormatted(elementPath, formatValue(actual.Index(i)), missingValue)
+		default:
+			differ.diff(elementPath, fieldPath, actual.Index(i), expected.Index(i))
+		}
+	}
+}
+
+func (differ *valueDiffer) diffMaps(path, fieldPath string, actual, expected reflect.Value) {
+	if isSameReference(actual, expected) {
+		return
+	}
+	if differ.isNilMismatch(actual, expected) {
+		differ.report(path, actual, expected)
+		return
+	}
+
+	keys := expected.MapKeys()
+	for _, key := range actual.MapKeys() {
+		if !expected.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return formatValue(keys[i]) < formatValue(keys[j]) })
+
+	for _, key := range keys {
+		elementPath := fmt.Sprintf("%s[%s]", path, formatValue(key))
+		actualElement, expectedElement := actual.MapIndex(key), expected.MapIndex(key)
+		switch {
+		case !actualElement.IsValid():
+			differ.reportFormatted(elementPath, missingValue, formatValue(expectedElement))
+		case !expectedElement.IsValid():
+			differ.reportFormatted(elementPath, formatValue(actualElement), missingValue)
+		default:
+			differ.diff(elementPath, fieldPath, actualElement, expectedElement)
+		}
+	}
+}
+
+// isNilMismatch returns true if one of the specified slices (or maps) is nil
+// while the other isn't, unless both are empty and such are equated.
+func (differ *valueDiffer) isNilMismatch(actual, expected reflect.Value) bool {
+	if actual.IsNil() == expected.IsNil() {
+		return false
+	}
+	return !differ.options.equateEmpty || actual.Len() != 0 || expected.Len() != 0
+}
+
+// isSameReference returns true if the specified slices (or maps) share
+// the same underlying data; like reflect.DeepEqual, such are deemed equal.
+func isSameReference(actual, expected reflect.Value) bool {
+	return !actual.IsNil() && actual.Pointer() == expected.Pointer() && actual.Len() == expected.Len()
+}
+
+// isVisited marks the references held by the specified values as visited,
+// and returns true if they were visited before.
+func (differ *valueDiffer) isVisited(actual, expected reflect.Value) bool {
+	switch actual.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if actual.IsNil() || expected.IsNil() {
+			return false
+		}
+		key := visit{actual.Pointer(), expected.Pointer(), 0, actual.Type()}
+		if actual.Kind() == reflect.Slice {
+			key.length = actual.Len()
+		}
+		if differ.visited[key] {
+			return true
+		}
+		differ.visited[key] = true
+	}
+	return false
+}
+
+// sorted returns a sorted copy of the specified slice if a matching sort key
+// is specified in the equality options; otherwise, the slice itself.
+func (differ *valueDiffer) sorted(slice reflect.Value) reflect.Value {
+	key, ok := differ.options.sortKeyFor(slice.Type().Elem())
+	if !ok || slice.Len() < 2 {
+		return slice
+	}
+
+	keys := make([]reflect.Value, slice.Len())
+	indices := make([]int, slice.Len())
+	for i := range keys {
+		keys[i] = key.Call([]reflect.Value{slice.Index(i)})[0]
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return isOrderedBefore(keys[indices[i]], keys[indices[j]]) })
+
+	sortedSlice := reflect.MakeSlice(slice.Type(), slice.Len(), slice.Len())
+	for i, index := range indices {
+		sortedSlice.Index(i).Set(slice.Index(index))
+	}
+	return sortedSlice
+}
+
+func (differ *valueDiffer) report(path string, actual, expected reflect.Value) {
+	differ.reportFormatted(path, formatValue(actual), formatValue(expected))
+}
+
+func (differ *valueDiffer) reportFormatted(path, actual, expected string) {
+	differ.differences = append(differ.differences, &valueDifference{path: path, actual: actual, expected: expected})
+}
+
+// accessible returns the specified value such that it's addressable and its
+// content can be read even if it was reached via unexported fields.
+// Values reached via fields, pointers, and slice elements of an accessible
+// value are addressable; others (e.g., map elements) are copied.
+func accessible(value reflect.Value) reflect.Value {
+	switch {
+	case !value.IsValid():
+		return value
+	case !value.CanAddr():
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		return addressable
+	case !value.CanInterface():
+		return reflect.NewAt(value.Type(), unsafe.Pointer(value.UnsafeAddr())).Elem()
+	}
+	return value
+}
+
+func isOrderedBefore(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", value.Interface())
+}
+
+func formatTypedValue(value reflect.Value) string {
+	return fmt.Sprintf("%s=%v", value.Type(), value.Interface())
+}
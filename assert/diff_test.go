@@ -0,0 +1,40 @@
+package assert
+
+import "fmt"
+
+type node struct {
+	Name     string
+	Value    interface{}
+	Children map[int]*node
+	Parent   *node
+}
+
+func ExamplePrintDiff() {
+	type lineItem struct{ SKU string }
+	type purchase struct{ Items []lineItem }
+	type customer struct{ Orders []purchase }
+
+	actual := customer{Orders: []purchase{{}, {}, {Items: []lineItem{{SKU: "A1"}}}}}
+	expected := customer{Orders: []purchase{{}, {}, {Items: []lineItem{{SKU: "A2"}, {SKU: "B1"}}}}}
+	PrintDiff(actual, expected)
+	// Output:
+	// Diff:
+	// Actual.Orders[2].Items[0].SKU: "A1" != "A2"
+	// Actual.Orders[2].Items[1]: (missing) != assert.lineItem{SKU:"B1"}
+}
+
+func ExampleAssertableValue_Equals_nestedValues() {
+	actual := &node{Name: "root", Children: map[int]*node{1: {Name: "a", Value: 42}, 2: {Name: "b"}}}
+	expected := &node{Name: "root", Children: map[int]*node{1: {Name: "a", Value: int64(42)}, 3: {Name: "c"}}}
+	actual.Children[1].Parent, expected.Children[1].Parent = actual, expected // cycles are only followed once
+
+	if !mockTestContextToAssert().ThatActual(actual).Equals(expected).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Value mismatch (3 differences in *assert.node).
+	// Actual.Children[1].Value: int=42 != int64=42
+	// Actual.Children[2]: &assert.node{Name:"b", Value:interface {}(nil), Children:map[int]*assert.node(nil), Parent:(*assert.node)(nil)} != (missing)
+	// Actual.Children[3]: (missing) != &assert.node{Name:"c", Value:interface {}(nil), Children:map[int]*assert.node(nil), Parent:(*assert.node)(nil)}
+	// Assertion failed successfully!
+}
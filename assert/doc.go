@@ -49,7 +49,7 @@ for post-assert actions to be performed; for example:
 
     assert.For(t).ThatActual(value).Equals(expected).ThenDiffOnFail()
 
-Which will pretty-print a detailed diff of both objects recursively on failure.
+Which will log the path of every differing leaf of both objects on failure.
 
 It also can be used as a condition to perform extra test steps:
 
@@ -76,6 +76,11 @@ Another use is to print a custom failure message:
 
 The above pattern allows for reuse of post-failure analysis and cleanup.
 
+Failures are logged via the test (see testing.TB.Log), so they're attributed
+to the right test or subtest, and to the line of the failed assertion in the
+test file. To print failures to stdout instead, located by this package's own
+caller detection, run tests with the -tester.detect-callers flag.
+
 The interfaces in this package are still a work-in-progress, and are subject
 to change.
 */
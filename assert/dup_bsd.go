@@ -0,0 +1,10 @@
+//go:build aix || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package assert
+
+import "syscall"
+
+// dup2 duplicates the specified old file descriptor onto the new one.
+func dup2(oldDescriptor, newDescriptor int) error {
+	return syscall.Dup2(oldDescriptor, newDescriptor)
+}
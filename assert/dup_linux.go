@@ -0,0 +1,9 @@
+package assert
+
+import "syscall"
+
+// dup2 duplicates the specified old file descriptor onto the new one; Linux
+// on some architectures (e.g., arm64) has dup3 only.
+func dup2(oldDescriptor, newDescriptor int) error {
+	return syscall.Dup3(oldDescriptor, newDescriptor, 0)
+}
@@ -0,0 +1,140 @@
+package assert
+
+import "reflect"
+
+// EqualityOption customizes how AssertableValue.EqualsWith compares values;
+// for example:
+//
+//     assert.For(t).ThatActual(order).EqualsWith(expected, assert.IgnoreFields("ID", "Items.CreatedAt"))
+//
+// Options apply to every level of the compared values.
+type EqualityOption func(*equalityOptions)
+
+type equalityOptions struct {
+	ignoredFieldPaths map[string]bool
+	ignoredFieldTags  []fieldTag
+	ignoreUnexported  bool
+	equateEmpty       bool
+	sortKeys          []reflect.Value
+	floatTolerance    float64
+	equateTimes       bool
+}
+
+// IgnoreFields ignores struct fields by their paths. A path is the dot-separated
+// names of the fields leading to the ignored one from the compared value,
+// skipping slice indices, array indices, and map keys; for example, "ID"
+// ignores the ID field of the compared struct, while "Items.CreatedAt" ignores
+// the CreatedAt field of every element in its Items field.
+func IgnoreFields(paths ...string) EqualityOption {
+	return func(options *equalityOptions) {
+		for _, path := range paths {
+			options.ignoredFieldPaths[path] = true
+		}
+	}
+}
+
+// IgnoreFieldsTagged ignores struct fields whose tag has the specified key
+// and value; an empty value ignores fields whose tag has the key regardless of
+// its value. For example, IgnoreFieldsTagged("compare", "ignore") ignores:
+//
+//     type order struct {
+//         ID string `compare:"ignore"`
+//     }
+func IgnoreFieldsTagged(key, value string) EqualityOption {
+	return func(options *equalityOptions) {
+		options.ignoredFieldTags = append(options.ignoredFieldTags, fieldTag{key, value})
+	}
+}
+
+// IgnoreUnexported ignores all unexported struct fields.
+func IgnoreUnexported() EqualityOption {
+	return func(options *equalityOptions) {
+		options.ignoreUnexported = true
+	}
+}
+
+// EquateEmpty treats nil and empty slices, as well as nil and empty maps,
+// as equal.
+func EquateEmpty() EqualityOption {
+	return func(options *equalityOptions) {
+		options.equateEmpty = true
+	}
+}
+
+// SortSlicesBy sorts slices whose elements can be passed to the specified key
+// function before comparing them; the key function must be of the form
+// func(T) K, where K is a string, an integer, or a float type. For example:
+//
+//     assert.SortSlicesBy(func(item Item) string { return item.SKU })
+//
+// Sorting is stable, and the compared values are left intact; however,
+// element indices in failure messages refer to the sorted slices.
+// SortSlicesBy panics if the specified key is nil or not a function of said
+// form.
+func SortSlicesBy(key interface{}) EqualityOption {
+	keyValue := reflect.ValueOf(key)
+	if !keyValue.IsValid() || keyValue.Kind() != reflect.Func || keyValue.IsNil() ||
+		keyValue.Type().NumIn() != 1 || keyValue.Type().NumOut() != 1 {
+		panic("assert: SortSlicesBy expects a key function of the form func(T) K")
+	}
+	return func(options *equalityOptions) {
+		options.sortKeys = append(options.sortKeys, keyValue)
+	}
+}
+
+// EquateFloats treats floating-point numbers that are at most the specified
+// tolerance apart as equal.
+func EquateFloats(tolerance float64) EqualityOption {
+	return func(options *equalityOptions) {
+		options.floatTolerance = tolerance
+	}
+}
+
+// EquateTimes compares time.Time values using time.Time.Equal instead of
+// comparing their fields; i.e., the same instant in different locations is
+// equal.
+func EquateTimes() EqualityOption {
+	return func(options *equalityOptions) {
+		options.equateTimes = true
+	}
+}
+
+// fieldTag is a key-value pair in a struct field's tag.
+type fieldTag struct {
+	key   string
+	value string
+}
+
+func newEqualityOptions(options ...EqualityOption) *equalityOptions {
+	equality := &equalityOptions{ignoredFieldPaths: map[string]bool{}}
+	for _, option := range options {
+		option(equality)
+	}
+	return equality
+}
+
+func (options *equalityOptions) isIgnoredField(fieldPath string, field reflect.StructField) bool {
+	if options.ignoreUnexported && field.PkgPath != "" {
+		return true
+	}
+	if options.ignoredFieldPaths[fieldPath] {
+		return true
+	}
+	for _, tag := range options.ignoredFieldTags {
+		if value, ok := field.Tag.Lookup(tag.key); ok && (tag.value == "" || tag.value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortKeyFor returns the key function to sort slices of the specified element
+// type by, if any.
+func (options *equalityOptions) sortKeyFor(elementType reflect.Type) (reflect.Value, bool) {
+	for _, key := range options.sortKeys {
+		if elementType.AssignableTo(key.Type().In(0)) {
+			return key, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,136 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type order struct {
+	ID        string `compare:"ignore"`
+	Items     []item
+	Notes     map[string]string
+	CreatedAt time.Time
+	Total     float64
+	cache     map[string]interface{}
+}
+
+type item struct {
+	SKU      string
+	Quantity int
+	Next     *item
+}
+
+func ExampleAssertableValue_EqualsWith_pass() {
+	now := time.Date(2018, time.July, 4, 12, 0, 0, 0, time.UTC)
+	tenth := 0.1
+	actual := &order{
+		ID:        "generated-1",
+		Items:     []item{{SKU: "B2", Quantity: 2}, {SKU: "A1", Quantity: 1}},
+		CreatedAt: now.In(time.FixedZone("PDT", -7*60*60)),
+		Total:     tenth + 0.2,
+		cache:     map[string]interface{}{"hit": true},
+	}
+	expected := &order{
+		ID:        "generated-2",
+		Items:     []item{{SKU: "A1", Quantity: 1}, {SKU: "B2", Quantity: 2}},
+		Notes:     map[string]string{},
+		CreatedAt: now,
+		Total:     0.3,
+	}
+
+	cases := []struct {
+		id      string
+		options []EqualityOption
+	}{
+		{"ignore fields by path", []EqualityOption{IgnoreFields("ID", "cache")}},
+		{"ignore fields by tag", []EqualityOption{IgnoreFieldsTagged("compare", "")}},
+	}
+
+	for _, c := range cases {
+		options := append(c.options, IgnoreUnexported(), EquateEmpty(), EquateFloats(1e-9), EquateTimes(),
+			SortSlicesBy(func(i item) string { return i.SKU }))
+		if For(t, c.id).ThatActual(actual).EqualsWith(expected, options...).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: ignore fields by path
+	// Passed: ignore fields by tag
+}
+
+func ExampleAssertableValue_EqualsWith_fail() {
+	now := time.Date(2018, time.July, 4, 12, 0, 0, 0, time.UTC)
+	actual := &order{
+		ID:        "generated-1",
+		Items:     []item{{SKU: "B2", Quantity: 3}, {SKU: "A1", Quantity: 1}},
+		CreatedAt: now.Add(time.Second),
+		cache:     map[string]interface{}{"hit": true},
+	}
+	expected := &order{
+		ID:        "generated-2",
+		Items:     []item{{SKU: "A1", Quantity: 1}, {SKU: "B2", Quantity: 2}, {SKU: "C3", Quantity: 1}},
+		Notes:     map[string]string{"gift": "yes"},
+		CreatedAt: now,
+		cache:     map[string]interface{}{"hit": false},
+	}
+
+	if !mockTestContextToAssert().ThatActual(actual).EqualsWith(expected,
+		IgnoreFields("ID"), EquateTimes(), SortSlicesBy(func(i item) string { return i.SKU })).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Value mismatch (5 differences in *assert.order).
+	// Actual.Items[1].Quantity: 3 != 2
+	// Actual.Items[2]: (missing) != assert.item{SKU:"C3", Quantity:1, Next:(*assert.item)(nil)}
+	// Actual.Notes: map[string]string(nil) != map[string]string{"gift":"yes"}
+	// Actual.CreatedAt: time.Date(2018, time.July, 4, 12, 0, 1, 0, time.UTC) != time.Date(2018, time.July, 4, 12, 0, 0, 0, time.UTC)
+	// Actual.cache["hit"]: true != false
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableValue_EqualsWith_cycles() {
+	actual, expected := &item{SKU: "A1"}, &item{SKU: "A1"}
+	actual.Next, expected.Next = actual, expected
+
+	if For(t).ThatActual(actual).EqualsWith(expected).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableValue_EqualsWith_typeMismatch() {
+	actual := []interface{}{1, "foo"}
+	expected := []interface{}{int64(1), "bar"}
+
+	if !mockTestContextToAssert().ThatActual(actual).EqualsWith(expected).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Value mismatch (2 differences in []interface {}).
+	// Actual[0]: int=1 != int64=1
+	// Actual[1]: "foo" != "bar"
+	// Assertion failed successfully!
+}
+
+func TestSortSlicesByRejectsInvalidKeys(t *testing.T) {
+	var nilKey func(item) string
+	cases := []struct {
+		id  string
+		key interface{}
+	}{
+		{"nil", nil},
+		{"nil function", nilKey},
+		{"not a function", "SKU"},
+		{"too many parameters", func(i, j item) string { return i.SKU }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.id, func(t *testing.T) {
+			defer func() {
+				For(t).ThatActual(recover()).Equals("assert: SortSlicesBy expects a key function of the form func(T) K")
+			}()
+			SortSlicesBy(c.key)
+		})
+	}
+}
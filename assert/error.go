@@ -1,11 +1,21 @@
 package assert
 
-import "reflect"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
 
 // AssertableError represents an under-test error that's expected to meet
 // certain criteria.
 type AssertableError interface {
-	// Equals asserts that the specified actual error equals the expected one.
+	// Equals asserts that the specified actual error equals the expected one;
+	// i.e., that their messages are equal or, if the expected error is an
+	// *ExpectedError, that the actual message matches it.
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	Equals(expected error) ValueAssertionResult
 
@@ -14,6 +24,22 @@ type AssertableError interface {
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	FormatsAs(text string) ValueAssertionResult
 
+	// ContainsMessage asserts that the specified actual error's message
+	// contains the specified substring.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsMessage(substring string) ValueAssertionResult
+
+	// HasMessagePrefix asserts that the specified actual error's message
+	// starts with the specified prefix.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasMessagePrefix(prefix string) ValueAssertionResult
+
+	// MatchesMessage asserts that the specified actual error's message
+	// matches the specified regular expression.
+	// See https://golang.org/pkg/regexp/syntax/ for the syntax.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MatchesMessage(pattern string) ValueAssertionResult
+
 	// IsNil asserts that the specified actual error is nil.
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	IsNil() ValueAssertionResult
@@ -21,6 +47,55 @@ type AssertableError interface {
 	// IsNotNil asserts that the specified actual error is not nil.
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	IsNotNil() ValueAssertionResult
+
+	// Is asserts that the specified actual error matches the target error as
+	// reported by errors.Is; i.e., that the target is the actual error or
+	// one of the errors it wraps, directly or via errors.Join.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Is(target error) ValueAssertionResult
+
+	// As asserts that the specified actual error, or one of the errors it
+	// wraps, can be assigned to the value that the specified target points to,
+	// and assigns it, as errors.As does; for example:
+	//
+	//     var pathError *fs.PathError
+	//     assert.For(t).ThatActualError(err).As(&pathError).Is(fs.ErrNotExist)
+	//
+	// Returns an AssertableError of the extracted error for further
+	// assertions; said error is nil if the assertion fails.
+	As(target interface{}) AssertableError
+
+	// HasCauseMatching asserts that the specified actual error, or one of the
+	// errors it wraps, satisfies the specified predicate.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasCauseMatching(predicate func(error) bool) ValueAssertionResult
+
+	// Unwraps asserts that the specified actual error wraps the specified
+	// number of levels of errors; i.e., that the longest chain of errors it
+	// wraps, directly or via errors.Join, is as deep as expected.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Unwraps(depth int) ValueAssertionResult
+
+	// ContainsError asserts that one of the errors that the specified actual
+	// error aggregates matches the specified target; see HasErrorCount for
+	// what's aggregated. An error matches a target if errors.Is reports so or
+	// if it equals the target as in Equals (e.g., with an *ExpectedError).
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsError(target error) ValueAssertionResult
+
+	// HasErrorCount asserts that the specified actual error aggregates the
+	// specified number of errors. Aggregates are errors that implement
+	// Unwrap() []error, like those errors.Join returns, whether wrapped or
+	// not; their errors are counted, and nested aggregates are flattened.
+	// Any other error counts as one, and a nil error counts as none.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasErrorCount(count int) ValueAssertionResult
+
+	// ContainsErrorsMatching asserts that each of the specified targets
+	// matches a distinct error that the specified actual error aggregates,
+	// in any order; see ContainsError for how errors match.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsErrorsMatching(targets ...error) ValueAssertionResult
 }
 
 type assertableError struct {
@@ -46,36 +121,295 @@ func (err ErrorString) Error() string {
 }
 
 func (actual *assertableError) Equals(expected error) ValueAssertionResult {
+	actual.testContext.Helper()
 	// Allow reflect to check for nil expected error as that object could have been loaded from JSON file (for DDT)
 	if expected == nil || (reflect.ValueOf(expected).Kind() == reflect.Ptr && reflect.ValueOf(expected).IsNil()) {
 		return actual.IsNil()
 	}
 	if actual.value == nil {
 		actual.testContext.decoratedErrorf("Error mismatch.\nActual was <nil>.\nExpected: %v\n", expected)
-		return &valueAssertionResult{bool: false, actual: actual.value, expected: expected}
+		return actual.testContext.resultOf(false, actual.value, expected)
 	}
 	// We're comparing interfaces — we only care about what Error() returns for both objects
 	areEqual := actual.value.Error() == expected.Error()
+	if expectedError, ok := expected.(*ExpectedError); ok {
+		areEqual = expectedError.matches(actual.value.Error())
+	}
 	if !areEqual {
 		actual.testContext.decoratedErrorf("Error mismatch.\nActual: %s\nExpected: %s\n", actual.value, expected)
 	}
-	return &valueAssertionResult{bool: areEqual, actual: actual.value, expected: expected}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
 }
 
 func (actual *assertableError) FormatsAs(text string) ValueAssertionResult {
+	actual.testContext.Helper()
 	return actual.Equals(ErrorString(text))
 }
 
+func (actual *assertableError) ContainsMessage(substring string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.Equals(&ExpectedError{Contains: substring})
+}
+
+func (actual *assertableError) HasMessagePrefix(prefix string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.Equals(&ExpectedError{Prefix: prefix})
+}
+
+func (actual *assertableError) MatchesMessage(pattern string) ValueAssertionResult {
+	actual.testContext.Helper()
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid regular expression.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.value, pattern)
+	}
+	return actual.Equals(&ExpectedError{Regex: regex})
+}
+
 func (actual *assertableError) IsNil() ValueAssertionResult {
+	actual.testContext.Helper()
 	if actual.value != nil { // no reflection here as we want to verify that the interface itself is not nil
 		actual.testContext.decoratedErrorf("Actual error was not <nil>.\nActual: %v\n", actual.value)
 	}
-	return &valueAssertionResult{bool: actual.value == nil, actual: actual.value, expected: nil}
+	return actual.testContext.resultOf(actual.value == nil, actual.value, nil)
 }
 
 func (actual *assertableError) IsNotNil() ValueAssertionResult {
+	actual.testContext.Helper()
 	if actual.value == nil { // no reflection here as we want to verify that the interface itself is nil
 		actual.testContext.decoratedErrorf("Actual error was <nil>.\n")
 	}
-	return &valueAssertionResult{bool: actual.value != nil, actual: actual.value, expected: &anyOtherValue{}}
+	return actual.testContext.resultOf(actual.value != nil, actual.value, &anyOtherValue{})
+}
+
+func (actual *assertableError) Is(target error) ValueAssertionResult {
+	actual.testContext.Helper()
+	isTarget := errors.Is(actual.value, target)
+	if !isTarget {
+		actual.testContext.decoratedErrorf("Error chain mismatch.\nActual chain:\n%sExpected to find: %s\n",
+			formatErrorChain(actual.value), formatError(target))
+	}
+	return actual.testContext.resultOf(isTarget, actual.value, target)
+}
+
+func (actual *assertableError) As(target interface{}) AssertableError {
+	actual.testContext.Helper()
+	targetValue := reflect.ValueOf(target)
+	if target == nil || targetValue.Kind() != reflect.Ptr || targetValue.IsNil() ||
+		(targetValue.Elem().Kind() != reflect.Interface && !targetValue.Type().Elem().Implements(errorType)) {
+		actual.testContext.decoratedErrorf("Invalid error target.\nActual: %T\n"+
+			"Expected a non-nil pointer to an interface or to a type that implements error.\n", target)
+		return &assertableError{testContext: actual.testContext}
+	}
+
+	if !errors.As(actual.value, target) {
+		actual.testContext.decoratedErrorf("Error chain mismatch.\nActual chain:\n%sExpected to find type: %s\n",
+			formatErrorChain(actual.value), targetValue.Type().Elem())
+		return &assertableError{testContext: actual.testContext}
+	}
+	extracted, _ := targetValue.Elem().Interface().(error)
+	return &assertableError{testContext: actual.testContext, value: extracted}
+}
+
+func (actual *assertableError) HasCauseMatching(predicate func(error) bool) ValueAssertionResult {
+	actual.testContext.Helper()
+	hasCause := hasErrorMatching(actual.value, predicate)
+	if !hasCause {
+		actual.testContext.decoratedErrorf("No error in the chain matched the predicate.\nActual chain:\n%s",
+			formatErrorChain(actual.value))
+	}
+	return actual.testContext.resultOf(hasCause, actual.value, predicate)
+}
+
+func (actual *assertableError) Unwraps(depth int) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualDepth := errorWrapDepth(actual.value)
+	if actualDepth != depth {
+		actual.testContext.decoratedErrorf("Error wrap depth mismatch.\nActual: %d\nExpected: %d\nActual chain:\n%s",
+			actualDepth, depth, formatErrorChain(actual.value))
+	}
+	return actual.testContext.resultOf(actualDepth == depth, actualDepth, depth)
+}
+
+func (actual *assertableError) ContainsError(target error) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.ContainsErrorsMatching(target)
+}
+
+func (actual *assertableError) HasErrorCount(count int) ValueAssertionResult {
+	actual.testContext.Helper()
+	errs := aggregatedErrors(actual.value)
+	if len(errs) != count {
+		actual.testContext.decoratedErrorf("Error count mismatch.\nActual: %d\nExpected: %d\nActual errors:\n%s",
+			len(errs), count, formatErrorList(errs))
+	}
+	return actual.testContext.resultOf(len(errs) == count, len(errs), count)
+}
+
+func (actual *assertableError) ContainsErrorsMatching(targets ...error) ValueAssertionResult {
+	actual.testContext.Helper()
+	errs := aggregatedErrors(actual.value)
+	unmatched := unmatchedErrorTargets(errs, targets)
+	if len(unmatched) > 0 {
+		actual.testContext.decoratedErrorf("Aggregated errors mismatch.\nActual errors:\n%sUnmatched targets:\n%s",
+			formatErrorList(errs), formatErrorList(unmatched))
+	}
+	return actual.testContext.resultOf(len(unmatched) == 0, actual.value, targets)
+}
+
+// aggregatedErrors returns the errors that the specified error aggregates;
+// see AssertableError.HasErrorCount.
+func aggregatedErrors(err error) []error {
+	for wrapped := err; wrapped != nil; {
+		switch typed := wrapped.(type) {
+		case interface{ Unwrap() []error }:
+			errs := []error{}
+			for _, member := range unwrapError(wrapped) {
+				errs = append(errs, aggregatedErrors(member)...)
+			}
+			return errs
+		case interface{ Unwrap() error }:
+			wrapped = typed.Unwrap()
+		default:
+			wrapped = nil
+		}
+	}
+
+	if err == nil {
+		return []error{}
+	}
+	return []error{err}
+}
+
+// unmatchedErrorTargets matches the specified targets with distinct errors
+// and returns the targets that are left unmatched. As a target may match
+// several errors (e.g., an *ExpectedError that matches a substring),
+// matches are reassigned, as needed, to match as many targets as possible.
+func unmatchedErrorTargets(errs []error, targets []error) []error {
+	targetIndexOfError := make([]int, len(errs))
+	for i := range targetIndexOfError {
+		targetIndexOfError[i] = -1
+	}
+
+	var match func(targetIndex int, isVisited []bool) bool
+	match = func(targetIndex int, isVisited []bool) bool {
+		for i, err := range errs {
+			if isVisited[i] || !errorMatches(err, targets[targetIndex]) {
+				continue
+			}
+			isVisited[i] = true
+			if targetIndexOfError[i] < 0 || match(targetIndexOfError[i], isVisited) {
+				targetIndexOfError[i] = targetIndex
+				return true
+			}
+		}
+		return false
+	}
+
+	unmatched := []error{}
+	for targetIndex, target := range targets {
+		if !match(targetIndex, make([]bool, len(errs))) {
+			unmatched = append(unmatched, target)
+		}
+	}
+	return unmatched
+}
+
+// errorMatches returns true if the specified error matches the specified
+// target; see AssertableError.ContainsError.
+func errorMatches(err error, target error) bool {
+	if errors.Is(err, target) {
+		return true
+	} else if err == nil || target == nil {
+		return false
+	} else if expectedError, ok := target.(*ExpectedError); ok {
+		return expectedError.matches(err.Error())
+	}
+	return err.Error() == target.Error()
+}
+
+// unwrapError returns the errors that the specified error wraps, whether it
+// implements Unwrap() error or, like errors.Join's, Unwrap() []error.
+func unwrapError(err error) []error {
+	var wrapped []error
+	switch typed := err.(type) {
+	case interface{ Unwrap() error }:
+		wrapped = []error{typed.Unwrap()}
+	case interface{ Unwrap() []error }:
+		wrapped = typed.Unwrap()
+	}
+
+	nonNil := []error{}
+	for _, err := range wrapped {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	return nonNil
+}
+
+func hasErrorMatching(err error, predicate func(error) bool) bool {
+	if err == nil {
+		return false
+	} else if predicate(err) {
+		return true
+	}
+	for _, wrapped := range unwrapError(err) {
+		if hasErrorMatching(wrapped, predicate) {
+			return true
+		}
+	}
+	return false
+}
+
+func errorWrapDepth(err error) int {
+	depth := 0
+	for _, wrapped := range unwrapError(err) {
+		if wrappedDepth := errorWrapDepth(wrapped) + 1; wrappedDepth > depth {
+			depth = wrappedDepth
+		}
+	}
+	return depth
+}
+
+// formatErrorChain formats the tree of errors that the specified error
+// wraps, one error per line, indented by its depth in the tree; e.g.,
+//     "load config: open config.yml: no such file" (*fmt.wrapError)
+//       "open config.yml: no such file" (*fs.PathError)
+//         "no such file" (syscall.Errno)
+func formatErrorChain(err error) string {
+	var builder strings.Builder
+	writeErrorChain(&builder, err, "  ")
+	return builder.String()
+}
+
+func writeErrorChain(builder *strings.Builder, err error, indent string) {
+	builder.WriteString(indent + formatError(err) + "\n")
+	for _, wrapped := range unwrapError(err) {
+		writeErrorChain(builder, wrapped, indent+"  ")
+	}
+}
+
+// formatErrorList formats the specified errors, one error per line.
+func formatErrorList(errs []error) string {
+	if len(errs) == 0 {
+		return "  (none)\n"
+	}
+	var builder strings.Builder
+	for _, err := range errs {
+		builder.WriteString("  " + formatError(err) + "\n")
+	}
+	return builder.String()
+}
+
+// formatError formats the specified error's quoted message followed by its
+// dynamic type; e.g., "no such file" (syscall.Errno). An *ExpectedError is
+// formatted by its criteria; e.g., a message containing "no such file".
+func formatError(err error) string {
+	if err == nil {
+		return "<nil>"
+	} else if expectedError, ok := err.(*ExpectedError); ok {
+		return expectedError.Error()
+	}
+	return fmt.Sprintf("%q (%T)", err.Error(), err)
 }
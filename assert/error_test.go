@@ -3,6 +3,8 @@ package assert
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 )
 
 func ExampleAssertableError_Equals_pass() {
@@ -137,6 +139,364 @@ func ExampleAssertableError_IsNotNil_fail() {
 	// Output:
 	// file:3: Actual error was <nil>.
 	// Diff:
-	// nil != &assert.anyOtherValue{}
+	// Actual: nil != &assert.anyOtherValue{}
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_Is_pass() {
+	err := fmt.Errorf("load config: %w", &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist})
+	cases := []struct {
+		id     string
+		actual error
+		target error
+	}{
+		{"same error", fs.ErrNotExist, fs.ErrNotExist},
+		{"wrapped error", err, fs.ErrNotExist},
+		{"joined error", errors.Join(fs.ErrPermission, err), fs.ErrNotExist},
+		{"nil errors", nil, nil},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualError(c.actual).Is(c.target).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: same error
+	// Passed: wrapped error
+	// Passed: joined error
+	// Passed: nil errors
+}
+
+func ExampleAssertableError_Is_fail() {
+	cases := []struct {
+		id     string
+		actual error
+		target error
+	}{
+		{"same message, different errors", errors.New("file does not exist"), fs.ErrNotExist},
+		{"joined errors", errors.Join(fmt.Errorf("open: %w", fs.ErrPermission), errors.New("close")), fs.ErrNotExist},
+		{"nil actual", nil, fs.ErrNotExist},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualError(c.actual).Is(c.target).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [same message, different errors] Error chain mismatch.
+	// Actual chain:
+	//   "file does not exist" (*errors.errorString)
+	// Expected to find: "file does not exist" (*errors.errorString)
+	// Assertion failed successfully!
+	// file:3: [joined errors] Error chain mismatch.
+	// Actual chain:
+	//   "open: permission denied\nclose" (*errors.joinError)
+	//     "open: permission denied" (*fmt.wrapError)
+	//       "permission denied" (*errors.errorString)
+	//     "close" (*errors.errorString)
+	// Expected to find: "file does not exist" (*errors.errorString)
+	// Assertion failed successfully!
+	// file:3: [nil actual] Error chain mismatch.
+	// Actual chain:
+	//   <nil>
+	// Expected to find: "file does not exist" (*errors.errorString)
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_As_pass() {
+	_, err := os.Open("no/such/file")
+	var pathError *fs.PathError
+	For(t).ThatActualError(fmt.Errorf("load config: %w", err)).As(&pathError).Is(fs.ErrNotExist)
+	fmt.Println(pathError.Path)
+	// Output: no/such/file
+}
+
+func ExampleAssertableError_As_fail() {
+	var pathError *fs.PathError
+	mockTestContextToAssert().ThatActualError(fmt.Errorf("load config: %w", fs.ErrNotExist)).As(&pathError)
+	mockTestContextToAssert().ThatActualError(fs.ErrNotExist).As(pathError)
+	// Output:
+	// file:3: Error chain mismatch.
+	// Actual chain:
+	//   "load config: file does not exist" (*fmt.wrapError)
+	//     "file does not exist" (*errors.errorString)
+	// Expected to find type: *fs.PathError
+	// file:3: Invalid error target.
+	// Actual: *fs.PathError
+	// Expected a non-nil pointer to an interface or to a type that implements error.
+}
+
+func ExampleAssertableError_HasCauseMatching_pass() {
+	err := fmt.Errorf("load config: %w", &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist})
+	isConfigFileError := func(err error) bool {
+		pathError, ok := err.(*fs.PathError)
+		return ok && pathError.Path == "config.yml"
+	}
+
+	if For(t).ThatActualError(err).HasCauseMatching(isConfigFileError).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableError_HasCauseMatching_fail() {
+	err := fmt.Errorf("load config: %w", &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist})
+	isTimeout := func(err error) bool {
+		timeout, ok := err.(interface{ Timeout() bool })
+		return ok && timeout.Timeout()
+	}
+
+	if !mockTestContextToAssert().ThatActualError(err).HasCauseMatching(isTimeout).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: No error in the chain matched the predicate.
+	// Actual chain:
+	//   "load config: open config.yml: file does not exist" (*fmt.wrapError)
+	//     "open config.yml: file does not exist" (*fs.PathError)
+	//       "file does not exist" (*errors.errorString)
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_Unwraps_pass() {
+	cases := []struct {
+		id       string
+		actual   error
+		expected int
+	}{
+		{"unwrapped error", errors.New("foo"), 0},
+		{"wrapped error", fmt.Errorf("bar: %w", errors.New("foo")), 1},
+		{"joined errors", errors.Join(errors.New("baz"), fmt.Errorf("bar: %w", errors.New("foo"))), 2},
+		{"nil error", nil, 0},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualError(c.actual).Unwraps(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: unwrapped error
+	// Passed: wrapped error
+	// Passed: joined errors
+	// Passed: nil error
+}
+
+func ExampleAssertableError_Unwraps_fail() {
+	err := fmt.Errorf("baz: %w", fmt.Errorf("bar: %w", errors.New("foo")))
+	if !mockTestContextToAssert().ThatActualError(err).Unwraps(1).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Error wrap depth mismatch.
+	// Actual: 2
+	// Expected: 1
+	// Actual chain:
+	//   "baz: bar: foo" (*fmt.wrapError)
+	//     "bar: foo" (*fmt.wrapError)
+	//       "foo" (*errors.errorString)
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_ContainsMessage_pass() {
+	if For(t).ThatActualError(errors.New("dial tcp 10.0.0.1:443: i/o timeout")).ContainsMessage("timeout").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableError_ContainsMessage_fail() {
+	cases := []struct {
+		id     string
+		actual error
+	}{
+		{"different message", errors.New("connection refused")},
+		{"nil error", nil},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualError(c.actual).ContainsMessage("timeout").Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [different message] Error mismatch.
+	// Actual: connection refused
+	// Expected: a message containing "timeout"
+	// Assertion failed successfully!
+	// file:3: [nil error] Error mismatch.
+	// Actual was <nil>.
+	// Expected: a message containing "timeout"
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_HasMessagePrefix_pass() {
+	if For(t).ThatActualError(errors.New("dial tcp 10.0.0.1:443: i/o timeout")).HasMessagePrefix("dial tcp").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableError_HasMessagePrefix_fail() {
+	err := errors.New("read tcp: i/o timeout")
+	if !mockTestContextToAssert().ThatActualError(err).HasMessagePrefix("dial").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Error mismatch.
+	// Actual: read tcp: i/o timeout
+	// Expected: a message starting with "dial"
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_MatchesMessage_pass() {
+	err := errors.New("dial tcp 10.0.0.1:443: i/o timeout")
+	if For(t).ThatActualError(err).MatchesMessage(`^dial tcp [\d.]+:\d+: i/o timeout$`).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableError_MatchesMessage_fail() {
+	cases := []struct {
+		id      string
+		pattern string
+	}{
+		{"mismatch", `^dial tcp [\d.]+:\d+: i/o timeout$`},
+		{"invalid pattern", `dial (tcp`},
+	}
+
+	err := errors.New("dial tcp: lookup example.com")
+	for _, c := range cases {
+		mockTestContextToAssert(c.id).ThatActualError(err).MatchesMessage(c.pattern)
+	}
+	// Output:
+	// file:3: [mismatch] Error mismatch.
+	// Actual: dial tcp: lookup example.com
+	// Expected: a message matching regex "^dial tcp [\\d.]+:\\d+: i/o timeout$"
+	// file:3: [invalid pattern] Invalid regular expression.
+	// Error: error parsing regexp: missing closing ): `dial (tcp`
+}
+
+// validationErrors is a user-defined aggregate error.
+type validationErrors []error
+
+func (errs validationErrors) Error() string {
+	return fmt.Sprintf("%d validation errors", len(errs))
+}
+
+func (errs validationErrors) Unwrap() []error {
+	return errs
+}
+
+func ExampleAssertableError_ContainsError_pass() {
+	errNameRequired := errors.New("name is required")
+	cases := []struct {
+		id     string
+		actual error
+		target error
+	}{
+		{"joined errors", errors.Join(errors.New("age must be positive"), errNameRequired), errNameRequired},
+		{"wrapped joined errors", fmt.Errorf("validate user: %w", errors.Join(errNameRequired)), errNameRequired},
+		{"custom aggregate", validationErrors{fmt.Errorf("user: %w", errNameRequired)}, errNameRequired},
+		{"same message", validationErrors{errNameRequired}, ErrorString("name is required")},
+		{"expected error", validationErrors{errNameRequired}, &ExpectedError{Contains: "name"}},
+		{"single error", errNameRequired, errNameRequired},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualError(c.actual).ContainsError(c.target).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: joined errors
+	// Passed: wrapped joined errors
+	// Passed: custom aggregate
+	// Passed: same message
+	// Passed: expected error
+	// Passed: single error
+}
+
+func ExampleAssertableError_ContainsError_fail() {
+	err := errors.Join(errors.New("age must be positive"), validationErrors{errors.New("email is invalid")})
+	if !mockTestContextToAssert().ThatActualError(err).ContainsError(errors.New("name is required")).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Aggregated errors mismatch.
+	// Actual errors:
+	//   "age must be positive" (*errors.errorString)
+	//   "email is invalid" (*errors.errorString)
+	// Unmatched targets:
+	//   "name is required" (*errors.errorString)
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_HasErrorCount_pass() {
+	cases := []struct {
+		id       string
+		actual   error
+		expected int
+	}{
+		{"joined errors", errors.Join(errors.New("foo"), errors.New("bar")), 2},
+		{"nested aggregates", validationErrors{errors.New("foo"), errors.Join(errors.New("bar"), nil)}, 2},
+		{"single error", fmt.Errorf("foo: %w", errors.New("bar")), 1},
+		{"nil error", nil, 0},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualError(c.actual).HasErrorCount(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: joined errors
+	// Passed: nested aggregates
+	// Passed: single error
+	// Passed: nil error
+}
+
+func ExampleAssertableError_HasErrorCount_fail() {
+	err := validationErrors{errors.New("name is required"), errors.New("age must be positive")}
+	if !mockTestContextToAssert().ThatActualError(err).HasErrorCount(3).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Error count mismatch.
+	// Actual: 2
+	// Expected: 3
+	// Actual errors:
+	//   "name is required" (*errors.errorString)
+	//   "age must be positive" (*errors.errorString)
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableError_ContainsErrorsMatching_pass() {
+	err := errors.Join(errors.New("name is required"), errors.New("name is too long"))
+	// The first target matches both errors; it's matched with the second one to match the second target as well.
+	if For(t).ThatActualError(err).ContainsErrorsMatching(
+		&ExpectedError{Prefix: "name"}, ErrorString("name is required")).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableError_ContainsErrorsMatching_fail() {
+	err := errors.Join(errors.New("name is required"), errors.New("age must be positive"))
+	if !mockTestContextToAssert().ThatActualError(err).ContainsErrorsMatching(
+		&ExpectedError{Contains: "age"}, &ExpectedError{Contains: "email"}, &ExpectedError{Contains: "age"}).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Aggregated errors mismatch.
+	// Actual errors:
+	//   "name is required" (*errors.errorString)
+	//   "age must be positive" (*errors.errorString)
+	// Unmatched targets:
+	//   a message containing "email"
+	//   a message containing "age"
 	// Assertion failed successfully!
 }
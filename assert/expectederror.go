@@ -0,0 +1,102 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExpectedError is an expected error that's specified by how its message
+// matches rather than by the message itself, so that tests don't break when
+// a dependency rewords its errors; for example:
+//
+//     assert.For(t).ThatActualError(err).Equals(&assert.ExpectedError{Contains: "timeout"})
+//
+// A non-nil actual error matches only if its message matches all the
+// specified criteria; an empty ExpectedError matches any non-nil error.
+// ExpectedError can be loaded from JSON; e.g., in a DDT test case, an expected
+// error can be specified as a literal message or as an object:
+//
+//     "error": "exact message"
+//     "error": {"contains": "timeout"}
+//     "error": {"prefix": "dial tcp"}
+//     "error": {"regex": "^dial tcp .+: i/o timeout$"}
+type ExpectedError struct {
+	// Message, if not empty, is the exact expected message.
+	Message string
+
+	// Contains, if not empty, is a substring of the expected message.
+	Contains string
+
+	// Prefix, if not empty, is a prefix of the expected message.
+	Prefix string
+
+	// Regex, if not nil, matches the expected message.
+	Regex *regexp.Regexp
+}
+
+// Error describes the criteria that the expected message matches; e.g.,
+// a message containing "timeout".
+func (expected *ExpectedError) Error() string {
+	criteria := []string{}
+	if expected.Contains != "" {
+		criteria = append(criteria, "containing "+strconv.Quote(expected.Contains))
+	}
+	if expected.Prefix != "" {
+		criteria = append(criteria, "starting with "+strconv.Quote(expected.Prefix))
+	}
+	if expected.Regex != nil {
+		criteria = append(criteria, "matching regex "+strconv.Quote(expected.Regex.String()))
+	}
+	if expected.Message != "" {
+		return expected.Message
+	} else if len(criteria) == 0 {
+		return "any message"
+	}
+	return "a message " + strings.Join(criteria, " and ")
+}
+
+// UnmarshalJSON decodes an expected error from either a JSON string, which is
+// the exact expected message, or a JSON object whose optional properties are
+// "message", "contains", "prefix", and "regex".
+func (expected *ExpectedError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*expected = ExpectedError{Message: message}
+		return nil
+	} else if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return fmt.Errorf("assert: cannot decode expected error from %s; expected a string or an object", data)
+	}
+
+	var criteria struct {
+		Message  string  `json:"message"`
+		Contains string  `json:"contains"`
+		Prefix   string  `json:"prefix"`
+		Regex    *string `json:"regex"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&criteria); err != nil {
+		return fmt.Errorf("assert: cannot decode expected error: %v", err)
+	}
+
+	*expected = ExpectedError{Message: criteria.Message, Contains: criteria.Contains, Prefix: criteria.Prefix}
+	if criteria.Regex != nil {
+		regex, err := regexp.Compile(*criteria.Regex)
+		if err != nil {
+			return fmt.Errorf("assert: cannot decode expected error: %v", err)
+		}
+		expected.Regex = regex
+	}
+	return nil
+}
+
+func (expected *ExpectedError) matches(message string) bool {
+	return (expected.Message == "" || message == expected.Message) &&
+		strings.Contains(message, expected.Contains) &&
+		strings.HasPrefix(message, expected.Prefix) &&
+		(expected.Regex == nil || expected.Regex.MatchString(message))
+}
@@ -0,0 +1,64 @@
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+func ExampleExpectedError() {
+	err := errors.New("dial tcp 10.0.0.1:443: i/o timeout")
+	cases := []struct {
+		id       string
+		expected *ExpectedError
+	}{
+		{"message", &ExpectedError{Message: "dial tcp 10.0.0.1:443: i/o timeout"}},
+		{"all criteria", &ExpectedError{Contains: "i/o", Prefix: "dial", Regex: regexp.MustCompile(`timeout$`)}},
+		{"any message", &ExpectedError{}},
+		{"mismatch", &ExpectedError{Contains: "i/o", Prefix: "read"}},
+	}
+
+	for _, c := range cases {
+		if mockTestContextToAssert(c.id).ThatActualError(err).Equals(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: message
+	// Passed: all criteria
+	// Passed: any message
+	// file:3: [mismatch] Error mismatch.
+	// Actual: dial tcp 10.0.0.1:443: i/o timeout
+	// Expected: a message containing "i/o" and starting with "read"
+}
+
+func ExampleExpectedError_UnmarshalJSON() {
+	var expected struct {
+		Errors []*ExpectedError `json:"errors"`
+	}
+	err := json.Unmarshal([]byte(`{"errors": [
+		"i/o timeout",
+		{"contains": "timeout"},
+		{"prefix": "dial", "regex": "timeout$"},
+		null
+	]}`), &expected)
+
+	if For(t).ThatActualError(err).IsNil().Passed() {
+		for _, expectedError := range expected.Errors {
+			fmt.Printf("%v\n", expectedError)
+		}
+	}
+
+	for _, invalid := range []string{`{"regex": "("}`, `{"suffix": "timeout"}`, `42`} {
+		fmt.Println(json.Unmarshal([]byte(invalid), &ExpectedError{}))
+	}
+	// Output:
+	// i/o timeout
+	// a message containing "timeout"
+	// a message starting with "dial" and matching regex "timeout$"
+	// <nil>
+	// assert: cannot decode expected error: error parsing regexp: missing closing ): `(`
+	// assert: cannot decode expected error: json: unknown field "suffix"
+	// assert: cannot decode expected error from 42; expected a string or an object
+}
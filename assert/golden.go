@@ -0,0 +1,178 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/voicera/tester/internal/testname"
+)
+
+// updateGoldenFilesEnvironmentVariable enables updating golden files and
+// snapshots like the -tester.update flag does, if it's set to a true value;
+// e.g., "1".
+const updateGoldenFilesEnvironmentVariable = "TESTER_UPDATE"
+
+var (
+	// goldenDirectory is where golden files are stored, relative to the
+	// directory of the package under test.
+	goldenDirectory = "_golden"
+
+	unsafeFileNameCharacters = regexp.MustCompile(`[^\w.-]+`)
+
+	// usedGoldenFiles maps the paths of the golden files that were asserted
+	// against to the tests and names they were asserted against by, which
+	// may collide once they're made safe (e.g., "a:b" and "a_b"); it's
+	// guarded by usedGoldenFilesLock.
+	usedGoldenFiles     = map[string]string{}
+	usedGoldenFilesLock sync.Mutex
+)
+
+// UnusedGoldenFiles returns the paths of the golden files, under the
+// "_golden" directory of the package under test, that no assertion used
+// since the tests started. It's meant to be called by TestMain after the
+// tests ran in full (i.e., without the -run flag); for example:
+//
+//     func TestMain(m *testing.M) {
+//         code := m.Run()
+//         if unused, _ := assert.UnusedGoldenFiles(); len(unused) > 0 {
+//             fmt.Println("Unused golden files:", unused)
+//         }
+//         os.Exit(code)
+//     }
+func UnusedGoldenFiles() ([]string, error) {
+	usedGoldenFilesLock.Lock()
+	defer usedGoldenFilesLock.Unlock()
+	unused := []string{}
+	err := filepath.WalkDir(goldenDirectory, func(path string, entry fs.DirEntry, err error) error {
+		if _, isUsed := usedGoldenFiles[path]; err == nil && !entry.IsDir() && !isUsed {
+			unused = append(unused, path)
+		}
+		return err
+	})
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	sort.Strings(unused)
+	return unused, err
+}
+
+// matchesGoldenFile asserts that the specified actual content equals the
+// content of the specified golden file of the test, or updates the file if
+// tests are run with the -tester.update flag or the TESTER_UPDATE environment
+// variable. It fails if another test or name already used the same file;
+// e.g., subtests named "a:b" and "a_b".
+func (testContext *testContext) matchesGoldenFile(actual []byte, name string) ValueAssertionResult {
+	testContext.Helper()
+	path := testContext.goldenFilePath(name)
+	user := testContext.testName() + ": " + name
+	usedGoldenFilesLock.Lock()
+	otherUser, isUsed := usedGoldenFiles[path]
+	if !isUsed {
+		usedGoldenFiles[path] = user
+	}
+	usedGoldenFilesLock.Unlock()
+	if isUsed && otherUser != user {
+		testContext.decoratedErrorf("Golden file name collision.\nGolden file: %s\nTest: %s\nOther test: %s\n"+
+			"Rename either test or golden file so that their paths differ.\n", path, user, otherUser)
+		return testContext.resultOf(false, actual, nil)
+	}
+
+	if updatesGoldenFiles() {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, actual, 0644)
+		}
+		if err != nil {
+			testContext.decoratedErrorf("Golden file not updated.\nGolden file: %s\nError: %v\n", path, err)
+			return testContext.resultOf(false, actual, nil)
+		}
+		testContext.log("", noCallerInfoLineNumber, "Golden file updated: "+path+"\n")
+		return testContext.resultOf(true, actual, actual)
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		testContext.decoratedErrorf("Invalid golden file.\nGolden file: %s\nError: %v\n"+
+			"Run the test with the -tester.update flag to create it.\n", path, err)
+		return testContext.resultOf(false, actual, nil)
+	}
+
+	areEqual := bytes.Equal(actual, expected)
+	if !areEqual {
+		testContext.decoratedErrorf("Golden file mismatch.\nGolden file: %s\n%s"+
+			"Run the test with the -tester.update flag to update it.\n", path, formatContentDiff(actual, expected))
+	}
+	return testContext.resultOf(areEqual, actual, expected)
+}
+
+func updatesGoldenFiles() bool {
+	isSet, _ := strconv.ParseBool(os.Getenv(updateGoldenFilesEnvironmentVariable))
+	return *updatesGolden || isSet
+}
+
+// goldenFilePath returns "_golden/<test name>/<name>" where the test name is
+// that of the test (e.g., "TestRender/dark_mode"), with each subtest in its
+// own directory and characters that aren't safe in file names replaced.
+func (testContext *testContext) goldenFilePath(name string) string {
+	segments := strings.Split(testContext.testName(), "/")
+	for i, segment := range segments {
+		segments[i] = safeFileName(segment)
+	}
+	return filepath.Join(append(append([]string{goldenDirectory}, segments...), name)...)
+}
+
+// testName returns the name of the test; e.g., "TestRender/dark_mode".
+func (testContext *testContext) testName() string {
+	if name := testContext.Name(); name != "" {
+		return name
+	}
+	if name, ok := testname.OfCaller(); ok { // e.g., in examples
+		return name
+	}
+	return "Unknown"
+}
+
+// safeFileName replaces the characters of the specified name that aren't
+// safe in file names with underscores.
+func safeFileName(name string) string {
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return unsafeFileNameCharacters.ReplaceAllString(name, "_")
+}
+
+// formatContentDiff formats the specified contents like string mismatches
+// are if both are text; otherwise, it formats their sizes and the first
+// differing byte.
+func formatContentDiff(actual, expected []byte) string {
+	if !utf8.Valid(actual) || !utf8.Valid(expected) {
+		return formatBinaryDiff(actual, expected)
+	} else if isMultiline(string(actual), string(expected)) {
+		return formatLineDiff(string(actual), string(expected))
+	}
+	return fmt.Sprintf("Actual: %q\nExpected: %q\n", actual, expected)
+}
+
+func formatBinaryDiff(actual, expected []byte) string {
+	i := 0
+	for i < len(actual) && i < len(expected) && actual[i] == expected[i] {
+		i++
+	}
+	describeByteAt := func(content []byte) string {
+		if i < len(content) {
+			return fmt.Sprintf("0x%02x", content[i])
+		}
+		return "end of content"
+	}
+	return fmt.Sprintf("Actual: %d B\nExpected: %d B\nFirst difference: byte %d; actual %s, expected %s\n",
+		len(actual), len(expected), i, describeByteAt(actual), describeByteAt(expected))
+}
@@ -0,0 +1,123 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func ExampleAssertableString_MatchesGoldenFile_pass() {
+	letter := fmt.Sprintf("Dear %s,\nYour order has shipped.\nRegards\n", "Ann")
+	if For(t).ThatActualString(letter).MatchesGoldenFile("letter.txt").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_MatchesGoldenFile_fail() {
+	letter := fmt.Sprintf("Dear %s,\nYour order has been delivered.\nRegards\n", "Ann")
+	mockTestContextToAssert().ThatActualString(letter).MatchesGoldenFile("letter.txt")
+	mockTestContextToAssert().ThatActualString(letter).MatchesGoldenFile("missing.txt")
+	// Output:
+	// file:3: Golden file mismatch.
+	// Golden file: _golden/ExampleAssertableString_MatchesGoldenFile_fail/letter.txt
+	// --- Actual
+	// +++ Expected
+	// @@ -1,3 +1,3 @@
+	//  Dear Ann,
+	// -Your order has been delivered.
+	// +Your order has shipped.
+	//  Regards
+	// First difference: line 2, column 16; actual 'b' (U+0062), expected 's' (U+0073)
+	// Run the test with the -tester.update flag to update it.
+	// file:3: Invalid golden file.
+	// Golden file: _golden/ExampleAssertableString_MatchesGoldenFile_fail/missing.txt
+	// Error: open _golden/ExampleAssertableString_MatchesGoldenFile_fail/missing.txt: no such file or directory
+	// Run the test with the -tester.update flag to create it.
+}
+
+func ExampleAssertableBytes_MatchesGoldenFile_fail() {
+	logo := []byte("\x89PNG\r\n\x1a\n\x00\x01")
+	mockTestContextToAssert().ThatActualBytes(logo).MatchesGoldenFile("logo.png")
+	// Output:
+	// file:3: Golden file mismatch.
+	// Golden file: _golden/ExampleAssertableBytes_MatchesGoldenFile_fail/logo.png
+	// Actual: 10 B
+	// Expected: 10 B
+	// First difference: byte 9; actual 0x01, expected 0x00
+	// Run the test with the -tester.update flag to update it.
+}
+
+func TestGoldenFilePathsOfSubtests(t *testing.T) {
+	cases := []struct {
+		subtest  string
+		expected string
+	}{
+		{"dark mode", "_golden/TestGoldenFilePathsOfSubtests/dark_mode/page.html"},
+		{"locale=fr-FR", "_golden/TestGoldenFilePathsOfSubtests/locale_fr-FR/page.html"},
+		{"nested/..", "_golden/TestGoldenFilePathsOfSubtests/nested/_/page.html"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.subtest, func(t *testing.T) {
+			path := For(t).(*testContext).goldenFilePath("page.html")
+			For(t, c.subtest).ThatActualString(path).Equals(filepath.FromSlash(c.expected))
+		})
+	}
+}
+
+func TestGoldenFilesAreUpdated(t *testing.T) {
+	defer func(original string) { goldenDirectory = original }(goldenDirectory)
+	goldenDirectory = filepath.Join(t.TempDir(), "_golden")
+	path := filepath.Join(goldenDirectory, "TestGoldenFilesAreUpdated", "page.html")
+
+	t.Setenv(updateGoldenFilesEnvironmentVariable, "1")
+	recorder := &logRecorder{TB: t}
+	For(t).ThatActual(For(recorder).ThatActualString("<p>new</p>\n").MatchesGoldenFile("page.html").Passed()).IsTrue()
+	For(t).ThatActualCollection(recorder.logs).ContainsInOrder("Golden file updated: " + path)
+	content, err := os.ReadFile(path)
+	For(t).ThatActualError(err).IsNil()
+	For(t).ThatActualString(string(content)).Equals("<p>new</p>\n")
+
+	t.Setenv(updateGoldenFilesEnvironmentVariable, "")
+	For(t).ThatActualString("<p>new</p>\n").MatchesGoldenFile("page.html")
+	For(t).ThatActualBytes([]byte("<p>new</p>\n")).MatchesGoldenFile("page.html")
+}
+
+func TestUnusedGoldenFiles(t *testing.T) {
+	defer func(original string) { goldenDirectory = original }(goldenDirectory)
+	goldenDirectory = t.TempDir()
+	for _, name := range []string{"used.txt", "unused.txt"} {
+		path := filepath.Join(goldenDirectory, "TestUnusedGoldenFiles", name)
+		For(t, name).ThatActualError(os.MkdirAll(filepath.Dir(path), 0755)).IsNil()
+		For(t, name).ThatActualError(os.WriteFile(path, []byte(name), 0644)).IsNil()
+	}
+
+	For(t).ThatActualString("used.txt").MatchesGoldenFile("used.txt")
+	unused, err := UnusedGoldenFiles()
+	For(t).ThatActualError(err).IsNil()
+	For(t).ThatActual(unused).Equals([]string{filepath.Join(goldenDirectory, "TestUnusedGoldenFiles", "unused.txt")})
+}
+
+func TestGoldenFileNameCollisionsAreReported(t *testing.T) {
+	defer func(original string) { goldenDirectory = original }(goldenDirectory)
+	goldenDirectory = t.TempDir()
+	path := filepath.Join(goldenDirectory, "TestGoldenFileNameCollisionsAreReported", "a_b", "page.html")
+	For(t).ThatActualError(os.MkdirAll(filepath.Dir(path), 0755)).IsNil()
+	For(t).ThatActualError(os.WriteFile(path, []byte("<p></p>"), 0644)).IsNil()
+
+	recorder := &logRecorder{TB: t}
+	for _, subtest := range []string{"a_b", "a:b"} {
+		t.Run(subtest, func(t *testing.T) {
+			recorder.TB = t
+			For(recorder).ThatActualString("<p></p>").MatchesGoldenFile("page.html")
+			For(recorder).ThatActualString("<p></p>").MatchesGoldenFile("page.html")
+		})
+	}
+	collision := "Golden file name collision.\nGolden file: " + path + "\n" +
+		"Test: TestGoldenFileNameCollisionsAreReported/a:b: page.html\n" +
+		"Other test: TestGoldenFileNameCollisionsAreReported/a_b: page.html\n" +
+		"Rename either test or golden file so that their paths differ."
+	For(t).ThatActual(recorder.logs).Equals([]string{collision, collision})
+}
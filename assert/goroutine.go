@@ -0,0 +1,97 @@
+package assert
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+var (
+	goroutineHeaderPattern    = regexp.MustCompile(`^goroutine (\d+) `)
+	goroutineCreatedByPattern = regexp.MustCompile(`\ncreated by (\S+)(?: in goroutine (\d+))?\n\t(\S+)`)
+)
+
+// goroutineStack is the stack of a goroutine as formatted by runtime.Stack.
+type goroutineStack struct {
+	id          int
+	creatorID   int    // 0 if unknown; e.g., for the main goroutine
+	createdBy   string // the function and the location that started the goroutine, if known
+	topFunction string // e.g., net/http.(*persistConn).readLoop
+	text        string
+}
+
+// currentGoroutineID returns the ID of the calling goroutine.
+func currentGoroutineID() int {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+	return parseGoroutineStack(string(buffer)).id
+}
+
+// allGoroutineStacks returns the stacks of all goroutines.
+func allGoroutineStacks() []*goroutineStack {
+	buffer := make([]byte, 1<<16)
+	for {
+		if n := runtime.Stack(buffer, true); n < len(buffer) {
+			buffer = buffer[:n]
+			break
+		}
+		buffer = make([]byte, 2*len(buffer))
+	}
+
+	stacks := []*goroutineStack{}
+	for _, text := range strings.Split(string(bytes.TrimSpace(buffer)), "\n\n") {
+		stacks = append(stacks, parseGoroutineStack(text))
+	}
+	return stacks
+}
+
+func parseGoroutineStack(text string) *goroutineStack {
+	stack := &goroutineStack{text: strings.TrimSpace(text)}
+	if match := goroutineHeaderPattern.FindStringSubmatch(stack.text); match != nil {
+		stack.id, _ = strconv.Atoi(match[1])
+	}
+	if match := goroutineCreatedByPattern.FindStringSubmatch(stack.text); match != nil {
+		stack.createdBy = match[1] + " at " + match[3]
+		stack.creatorID, _ = strconv.Atoi(match[2])
+	}
+	if lines := strings.SplitN(stack.text, "\n", 3); len(lines) > 1 {
+		if i := strings.LastIndex(lines[1], "("); i > 0 {
+			stack.topFunction = lines[1][:i]
+		}
+	}
+	return stack
+}
+
+// goroutineFamily returns the stacks of the goroutine whose ID is specified
+// and of the goroutines that it started, directly or indirectly, in that
+// order.
+func goroutineFamily(stacks []*goroutineStack, rootID int) []*goroutineStack {
+	family, isInFamily := []*goroutineStack{}, map[int]bool{rootID: true}
+	for hasGrown := true; hasGrown; {
+		hasGrown = false
+		for _, stack := range stacks {
+			if !isInFamily[stack.id] && isInFamily[stack.creatorID] {
+				isInFamily[stack.id], hasGrown = true, true
+			}
+		}
+	}
+	for _, stack := range stacks {
+		if isInFamily[stack.id] {
+			family = append(family, stack)
+		}
+	}
+	return family
+}
+
+func formatGoroutineStacks(stacks []*goroutineStack) string {
+	if len(stacks) == 0 {
+		return "(none)\n"
+	}
+	texts := make([]string, len(stacks))
+	for i, stack := range stacks {
+		texts[i] = stack.text
+	}
+	return strings.Join(texts, "\n\n") + "\n"
+}
@@ -0,0 +1,34 @@
+package assert
+
+import "testing"
+
+func TestGoroutineFamily(t *testing.T) {
+	stacks := []*goroutineStack{
+		parseGoroutineStack("goroutine 1 [chan receive]:\nmain.main()\n\t/app/main.go:10 +0x1d"),
+		parseGoroutineStack("goroutine 7 [select]:\napp.serve()\n\t/app/serve.go:20 +0x2e\n" +
+			"created by main.main in goroutine 1\n\t/app/main.go:9 +0x3f"),
+		parseGoroutineStack("goroutine 9 [chan send]:\napp.handle()\n\t/app/serve.go:30 +0x4a\n" +
+			"created by app.serve in goroutine 7\n\t/app/serve.go:19 +0x5b"),
+		parseGoroutineStack("goroutine 8 [sleep]:\napp.tick()\n\t/app/tick.go:5 +0x6c\n" +
+			"created by main.main in goroutine 1\n\t/app/main.go:8 +0x7d"),
+	}
+
+	For(t).ThatActual(stacks[2].id).Equals(9)
+	For(t).ThatActual(stacks[2].creatorID).Equals(7)
+	For(t).ThatActual(stacks[2].createdBy).Equals("app.serve at /app/serve.go:19")
+	For(t).ThatActual(stacks[2].topFunction).Equals("app.handle")
+	For(t).ThatActual(stacks[0].createdBy).Equals("")
+	family := goroutineFamily(stacks, 7)
+	if For(t).ThatActualCollection(family).HasLength(2).Passed() {
+		For(t).ThatActual(formatGoroutineStacks(family)).Equals(stacks[1].text + "\n\n" + stacks[2].text + "\n")
+	}
+	For(t).ThatActual(formatGoroutineStacks(goroutineFamily(stacks, 42))).Equals("(none)\n")
+}
+
+func TestCurrentGoroutineID(t *testing.T) {
+	ids := make(chan int)
+	go func() { ids <- currentGoroutineID() }()
+	id := currentGoroutineID()
+	For(t).ThatActual(id > 0).IsTrue()
+	For(t).ThatActual(<-ids != id).IsTrue()
+}
@@ -0,0 +1,518 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonRootPointer    = "(root)"
+	jsonMatchesPointer = "(matches)"
+	jsonPathRoot       = "$"
+	jsonPathWildcard   = "*"
+)
+
+// errNoJSONPathMatch reports that a JSONPath expression matches no value.
+var errNoJSONPathMatch = errors.New("no value matches JSON path")
+
+// AssertableJSON represents an under-test JSON document that's expected to
+// meet certain criteria. Documents are compared semantically: object key
+// order is ignored, and numbers are compared by value (e.g., 1.0 equals 1e0).
+// Differences are reported as JSON Pointer paths (RFC 6901).
+type AssertableJSON interface {
+	// Equals asserts that the specified actual JSON document is equivalent to
+	// the expected one, which is a JSON document specified as a []byte,
+	// a string, a json.RawMessage, or an io.Reader.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Equals(expected interface{}) ValueAssertionResult
+
+	// EqualsValue asserts that the specified actual JSON document is
+	// equivalent to the JSON encoding of the expected value.
+	// See https://golang.org/pkg/encoding/json/#Marshal for encoding details.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	EqualsValue(expected interface{}) ValueAssertionResult
+
+	// IgnoringArrayOrder returns an AssertableJSON that compares arrays
+	// regardless of the order of their elements; for example:
+	//     assert.For(t).ThatActualJSON(body).IgnoringArrayOrder().Equals(`{"tags": ["b", "a"]}`)
+	IgnoringArrayOrder() AssertableJSON
+
+	// At selects the values that match the specified JSONPath expression and
+	// returns an AssertableJSON for them; for example:
+	//     assert.For(t).ThatActualJSON(body).At("$.users[0].name").EqualsValue("Ann")
+	// A JSONPath expression starts with $ and may be followed by any number of
+	// .name, ['name'], [index], .*, and [*] selectors; a negative index counts
+	// from the end of the array, and a quoted name is a member name even if
+	// it's * or a number. An expression with a wildcard selects a JSON array
+	// of all matched values, whose differences are reported at the JSON
+	// Pointers of the matches (e.g., /users/1/name); differences in the
+	// number of matches are reported as (match N), and ones of the array
+	// as a whole as (matches).
+	At(path string) AssertableJSON
+
+	// MatchesSchema asserts that the specified actual JSON document is valid
+	// against the JSON Schema in the specified file. The following JSON Schema
+	// (draft 7) keywords are supported: type, enum, const, properties,
+	// required, additionalProperties, items, minItems, maxItems, uniqueItems,
+	// minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength,
+	// maxLength, pattern, allOf, anyOf, oneOf, not, and local $ref
+	// (e.g., "#/definitions/user"); other keywords are ignored.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MatchesSchema(schemaFile string) ValueAssertionResult
+}
+
+type assertableJSON struct {
+	testContext      *testContext
+	value            interface{}
+	pointer          string
+	matchPointers    []string // the pointers of the matches if the value is a wildcard selection
+	path             string   // the JSONPath expression that selected the value, if any
+	err              error
+	pathErr          error
+	ignoreArrayOrder bool
+}
+
+func newAssertableJSON(testContext *testContext, document interface{}) *assertableJSON {
+	value, err := decodeJSON(document)
+	return &assertableJSON{testContext: testContext, value: value, err: err}
+}
+
+func (actual *assertableJSON) Equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	expectedValue, err := decodeJSON(expected)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid expected JSON.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+	return actual.equals(expectedValue)
+}
+
+func (actual *assertableJSON) EqualsValue(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	expectedValue, err := marshalToJSONValue(expected)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid expected JSON.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+	return actual.equals(expectedValue)
+}
+
+func (actual *assertableJSON) IgnoringArrayOrder() AssertableJSON {
+	unordered := *actual
+	unordered.ignoreArrayOrder = true
+	return &unordered
+}
+
+func (actual *assertableJSON) At(path string) AssertableJSON {
+	selected := *actual
+	if actual.err == nil && actual.pathErr == nil {
+		selected.path = path
+		selected.value, selected.pointer, selected.matchPointers, selected.pathErr =
+			selectJSONPath(actual.value, actual.pointer, actual.matchPointers, path)
+	}
+	return &selected
+}
+
+func (actual *assertableJSON) MatchesSchema(schemaFile string) ValueAssertionResult {
+	actual.testContext.Helper()
+	if actual.err != nil || actual.pathErr != nil {
+		return actual.unselectableJSONResult(schemaFile)
+	}
+	schema, err := loadJSONSchema(schemaFile)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid JSON schema.\nFile: %s\nError: %v\n", schemaFile, err)
+		return actual.testContext.resultOf(false, actual.value, schemaFile)
+	}
+
+	schema.matchPointers = actual.matchPointers
+	violations := schema.validate(actual.pointer, actual.value)
+	if len(violations) > 0 {
+		actual.testContext.decoratedErrorf("JSON schema mismatch (%d %s).\nSchema: %s\n%s",
+			len(violations), pluralize(len(violations), "violation"), schemaFile, strings.Join(violations, "\n")+"\n")
+	}
+	return actual.testContext.resultOf(len(violations) == 0, actual.value, schemaFile)
+}
+
+func (actual *assertableJSON) equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	if actual.err != nil || actual.pathErr != nil {
+		return actual.unselectableJSONResult(expected)
+	}
+	var differences []*valueDifference
+	if actual.pointer == jsonMatchesPointer {
+		differences = diffJSONMatches(actual.matchPointers, actual.value, expected, actual.ignoreArrayOrder)
+	} else {
+		differences = diffJSON(actual.pointer, actual.value, expected, actual.ignoreArrayOrder)
+	}
+	if len(differences) > 0 {
+		actual.testContext.decoratedErrorf("JSON mismatch (%d %s).\n%s",
+			len(differences), pluralize(len(differences), "difference"), formatDifferences(differences))
+	}
+	return actual.testContext.resultOf(len(differences) == 0, actual.value, expected)
+}
+
+// unselectableJSONResult reports that the actual JSON document is invalid or
+// that the value to assert on couldn't be selected from it.
+func (actual *assertableJSON) unselectableJSONResult(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	switch {
+	case actual.err != nil:
+		actual.testContext.decoratedErrorf("Invalid actual JSON.\nError: %v\n", actual.err)
+	case actual.pathErr == errNoJSONPathMatch:
+		actual.testContext.decoratedErrorf("No value at path %s\n", actual.path)
+	default:
+		actual.testContext.decoratedErrorf("Invalid JSON path.\nError: %v\n", actual.pathErr)
+	}
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+// decodeJSON decodes the specified JSON document, which can be a []byte,
+// a string, a json.RawMessage, or an io.Reader, preserving numbers as
+// json.Number values.
+func decodeJSON(document interface{}) (interface{}, error) {
+	reader, err := documentReader(document, "JSON")
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level JSON value")
+	}
+	return value, nil
+}
+
+// documentReader returns a reader for the specified document of the specified
+// format, which can be a []byte (e.g., a json.RawMessage), a string,
+// or an io.Reader.
+func documentReader(document interface{}, format string) (io.Reader, error) {
+	switch typed := document.(type) {
+	case []byte:
+		return bytes.NewReader(typed), nil
+	case json.RawMessage:
+		return bytes.NewReader(typed), nil
+	case string:
+		return strings.NewReader(typed), nil
+	case io.Reader:
+		return typed, nil
+	}
+	return nil, fmt.Errorf("unsupported %s document type %T; expected []byte, string, or io.Reader", format, document)
+}
+
+func marshalToJSONValue(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(encoded)
+}
+
+// diffJSON compares the specified decoded JSON values and returns
+// the differences labeled by their JSON Pointer paths relative to the
+// specified one.
+func diffJSON(pointer string, actual, expected interface{}, ignoreArrayOrder bool) []*valueDifference {
+	differences := []*valueDifference{}
+	switch actualValue := actual.(type) {
+	case map[string]interface{}:
+		expectedValue, ok := expected.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range unionOfJSONKeys(actualValue, expectedValue) {
+			keyPointer := pointer + "/" + escapeJSONPointerToken(key)
+			actualElement, isInActual := actualValue[key]
+			expectedElement, isInExpected := expectedValue[key]
+			switch {
+			case !isInActual:
+				differences = append(differences, newJSONDifference(keyPointer, missingValue, expectedElement))
+			case !isInExpected:
+				differences = append(differences, newJSONDifference(keyPointer, actualElement, missingValue))
+			default:
+				differences = append(differences,
+					diffJSON(keyPointer, actualElement, expectedElement, ignoreArrayOrder)...)
+			}
+		}
+		return differences
+	case []interface{}:
+		expectedValue, ok := expected.([]interface{})
+		if !ok {
+			break
+		}
+		elementPointer := func(i int) string { return pointer + "/" + strconv.Itoa(i) }
+		return diffJSONElements(elementPointer, elementPointer, actualValue, expectedValue, ignoreArrayOrder)
+	}
+
+	if !areEqualJSONScalars(actual, expected) {
+		differences = append(differences, newJSONDifference(pointer, actual, expected))
+	}
+	return differences
+}
+
+// diffJSONMatches compares the specified matches of a wildcard selection,
+// whose JSON Pointers are specified, with the expected value; matches are
+// labeled by their pointers, and missing ones by their numbers.
+func diffJSONMatches(
+	pointers []string, actual, expected interface{}, ignoreArrayOrder bool) []*valueDifference {
+
+	actualMatches := actual.([]interface{})
+	expectedMatches, ok := expected.([]interface{})
+	if !ok {
+		return []*valueDifference{newJSONDifference(jsonMatchesPointer, actual, expected)}
+	}
+	actualPointer := func(i int) string { return pointers[i] }
+	expectedPointer := func(i int) string { return fmt.Sprintf("(match %d)", i+1) }
+	return diffJSONElements(actualPointer, expectedPointer, actualMatches, expectedMatches, ignoreArrayOrder)
+}
+
+// diffJSONElements compares the elements of the specified arrays in order,
+// or regardless of their order if so specified; elements are labeled by
+// the specified functions of their indices in the actual and expected arrays.
+func diffJSONElements(actualPointer, expectedPointer func(int) string,
+	actual, expected []interface{}, ignoreArrayOrder bool) []*valueDifference {
+
+	if ignoreArrayOrder {
+		return diffUnorderedJSONArrays(actualPointer, expectedPointer, actual, expected)
+	}
+	differences := []*valueDifference{}
+	for i := 0; i < len(actual) || i < len(expected); i++ {
+		switch {
+		case i >= len(actual):
+			differences = append(differences, newJSONDifference(expectedPointer(i), missingValue, expected[i]))
+		case i >= len(expected):
+			differences = append(differences, newJSONDifference(actualPointer(i), actual[i], missingValue))
+		default:
+			differences = append(differences, diffJSON(actualPointer(i), actual[i], expected[i], ignoreArrayOrder)...)
+		}
+	}
+	return differences
+}
+
+// diffUnorderedJSONArrays matches the elements of the specified arrays
+// regardless of their order; unexpected elements are reported at their indices
+// in the actual array, and missing ones at their indices in the expected array.
+func diffUnorderedJSONArrays(actualPointer, expectedPointer func(int) string,
+	actual, expected []interface{}) []*valueDifference {
+
+	isMatched := make([]bool, len(actual))
+	missing := []*valueDifference{}
+	for i, expectedElement := range expected {
+		found := false
+		for j, actualElement := range actual {
+			if !isMatched[j] && len(diffJSON("", actualElement, expectedElement, true)) == 0 {
+				isMatched[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, newJSONDifference(expectedPointer(i), missingValue, expectedElement))
+		}
+	}
+
+	differences := []*valueDifference{}
+	for j, actualElement := range actual {
+		if !isMatched[j] {
+			differences = append(differences, newJSONDifference(actualPointer(j), actualElement, missingValue))
+		}
+	}
+	return append(differences, missing...)
+}
+
+func areEqualJSONScalars(actual, expected interface{}) bool {
+	actualNumber, isActualNumber := actual.(json.Number)
+	expectedNumber, isExpectedNumber := expected.(json.Number)
+	if isActualNumber && isExpectedNumber {
+		actualRat, isActualValid := new(big.Rat).SetString(string(actualNumber))
+		expectedRat, isExpectedValid := new(big.Rat).SetString(string(expectedNumber))
+		return isActualValid && isExpectedValid && actualRat.Cmp(expectedRat) == 0
+	}
+	switch actual.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return actual == expected
+}
+
+func newJSONDifference(pointer string, actual, expected interface{}) *valueDifference {
+	if pointer == "" {
+		pointer = jsonRootPointer
+	}
+	return &valueDifference{path: pointer, actual: formatJSONValue(actual), expected: formatJSONValue(expected)}
+}
+
+func formatJSONValue(value interface{}) string {
+	if value == missingValue {
+		return missingValue
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+	return string(encoded)
+}
+
+func unionOfJSONKeys(actual, expected map[string]interface{}) []string {
+	keys := make([]string, 0, len(actual)+len(expected))
+	for key := range actual {
+		keys = append(keys, key)
+	}
+	for key := range expected {
+		if _, ok := actual[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// selectJSONPath selects the values that match the specified JSONPath
+// expression from the specified document, whose JSON Pointer is specified,
+// along with the pointers of its matches if it's a wildcard selection itself.
+// It returns the selected value and its JSON Pointer; if the expression has
+// a wildcard, the selected value is an array of all matches, its pointer is
+// jsonMatchesPointer, and the pointers of the matches are returned as well.
+func selectJSONPath(
+	document interface{}, pointer string, matchPointers []string, path string) (interface{}, string, []string, error) {
+
+	selectors, err := parseJSONPath(path)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	type match struct {
+		value   interface{}
+		pointer string
+	}
+	matches, hasWildcard := []match{{document, pointer}}, false
+	for _, selector := range selectors {
+		selected := []match{}
+		for _, m := range matches {
+			switch value := m.value.(type) {
+			case map[string]interface{}:
+				if selector.isWildcard() {
+					for _, key := range unionOfJSONKeys(value, nil) {
+						selected = append(selected, match{value[key], m.pointer + "/" + escapeJSONPointerToken(key)})
+					}
+				} else if element, ok := value[selector.name]; ok {
+					selected = append(selected, match{element, m.pointer + "/" + escapeJSONPointerToken(selector.name)})
+				}
+			case []interface{}:
+				if selector.isWildcard() {
+					for i, element := range value {
+						selected = append(selected, match{element, jsonElementPointer(m.pointer, i, matchPointers)})
+					}
+				} else if index, err := strconv.Atoi(selector.name); err == nil && !selector.isQuoted {
+					if index < 0 {
+						index += len(value)
+					}
+					if index >= 0 && index < len(value) {
+						elementPointer := jsonElementPointer(m.pointer, index, matchPointers)
+						selected = append(selected, match{value[index], elementPointer})
+					}
+				}
+			}
+		}
+		matches, hasWildcard = selected, hasWildcard || selector.isWildcard()
+	}
+
+	switch {
+	case hasWildcard:
+		values, pointers := make([]interface{}, len(matches)), make([]string, len(matches))
+		for i, m := range matches {
+			values[i], pointers[i] = m.value, m.pointer
+		}
+		return values, jsonMatchesPointer, pointers, nil
+	case len(matches) == 0:
+		return nil, "", nil, errNoJSONPathMatch
+	}
+	return matches[0].value, matches[0].pointer, nil, nil
+}
+
+// jsonElementPointer returns the JSON Pointer of the element at the specified
+// index of the array at the specified pointer; the elements of a wildcard
+// selection have the specified pointers of their own.
+func jsonElementPointer(pointer string, index int, matchPointers []string) string {
+	if pointer == jsonMatchesPointer && index < len(matchPointers) {
+		return matchPointers[index]
+	}
+	return pointer + "/" + strconv.Itoa(index)
+}
+
+// jsonPathSelector is a selector of a JSONPath expression; i.e., a member
+// name, an array index, or a wildcard.
+type jsonPathSelector struct {
+	name     string
+	isQuoted bool // i.e., a member name even if it's a wildcard or a number
+}
+
+func (selector jsonPathSelector) isWildcard() bool {
+	return !selector.isQuoted && selector.name == jsonPathWildcard
+}
+
+// parseJSONPath splits the specified JSONPath expression into selectors.
+func parseJSONPath(path string) ([]jsonPathSelector, error) {
+	if !strings.HasPrefix(path, jsonPathRoot) {
+		return nil, fmt.Errorf("JSON path %s does not start with %s", path, jsonPathRoot)
+	}
+
+	selectors := []jsonPathSelector{}
+	for remaining := path[len(jsonPathRoot):]; remaining != ""; {
+		switch {
+		case strings.HasPrefix(remaining, ".."):
+			return nil, fmt.Errorf("JSON path %s has unsupported recursive descent", path)
+		case remaining[0] == '.':
+			end := strings.IndexAny(remaining[1:], ".[") + 1
+			if end == 0 {
+				end = len(remaining)
+			}
+			if end == 1 {
+				return nil, fmt.Errorf("JSON path %s has an empty member name", path)
+			}
+			selectors, remaining = append(selectors, jsonPathSelector{name: remaining[1:end]}), remaining[end:]
+		case remaining[0] == '[':
+			end := strings.Index(remaining, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("JSON path %s has an unclosed bracket", path)
+			}
+			selector := jsonPathSelector{name: remaining[1:end]}
+			if name := selector.name; len(name) >= 2 && strings.ContainsRune(`'"`, rune(name[0])) &&
+				name[len(name)-1] == name[0] {
+				selector = jsonPathSelector{name: name[1 : len(name)-1], isQuoted: true}
+			} else if _, err := strconv.Atoi(name); err != nil && name != jsonPathWildcard {
+				return nil, fmt.Errorf("JSON path %s has an invalid selector [%s]", path, name)
+			}
+			selectors, remaining = append(selectors, selector), remaining[end+1:]
+		default:
+			return nil, fmt.Errorf("JSON path %s has an unexpected character %q", path, remaining[0])
+		}
+	}
+	return selectors, nil
+}
+
+func loadJSONSchema(schemaFile string) (*jsonSchema, error) {
+	content, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+	document, err := decodeJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonSchema{root: document, definition: document}, nil
+}
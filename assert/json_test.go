@@ -0,0 +1,233 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+func ExampleAssertableJSON_Equals_pass() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"key order", `{"a": 1, "b": 2}`, `{"b": 2, "a": 1}`},
+		{"number formatting", []byte(`[1, 1.0, 100, 0.5]`), `[1e0, 1, 1E2, 5e-1]`},
+		{"readers", strings.NewReader(`{"a": [null, true]}`), strings.NewReader(`{"a":[null,true]}`)},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualJSON(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: key order
+	// Passed: number formatting
+	// Passed: readers
+}
+
+func ExampleAssertableJSON_Equals_fail() {
+	actual := `{"users": [{"name": "Ann", "age": 42}, {"name": "Bob"}], "a/b": 1, "extra": true}`
+	expected := `{"users": [{"name": "Ann", "age": 42.5}, {"name": "Bob", "age": 7}, {"name": "Eve"}], "a/b": "1"}`
+
+	if !mockTestContextToAssert().ThatActualJSON(actual).Equals(expected).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: JSON mismatch (5 differences).
+	// /a~1b: 1 != "1"
+	// /extra: true != (missing)
+	// /users/0/age: 42 != 42.5
+	// /users/1/age: (missing) != 7
+	// /users/2: (missing) != {"name":"Eve"}
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableJSON_Equals_invalidJSON() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"invalid actual", `{"a":`, `{}`},
+		{"trailing data", `{} {}`, `{}`},
+		{"invalid expected", `{}`, 42},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualJSON(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [invalid actual] Invalid actual JSON.
+	// Error: unexpected EOF
+	// Assertion failed successfully!
+	// file:3: [trailing data] Invalid actual JSON.
+	// Error: invalid character after top-level JSON value
+	// Assertion failed successfully!
+	// file:3: [invalid expected] Invalid expected JSON.
+	// Error: unsupported JSON document type int; expected []byte, string, or io.Reader
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableJSON_EqualsValue() {
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	if For(t).ThatActualJSON(`{"age": 42, "name": "Ann"}`).EqualsValue(&user{Name: "Ann", Age: 42}).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableJSON_IgnoringArrayOrder() {
+	actual := `{"tags": ["b", "a", "c", "a"]}`
+	if For(t).ThatActualJSON(actual).IgnoringArrayOrder().Equals(`{"tags": ["a", "a", "b", "c"]}`).Passed() {
+		fmt.Println("Passed!")
+	}
+
+	mockTestContextToAssert().ThatActualJSON(actual).IgnoringArrayOrder().Equals(`{"tags": ["a", "b", "d"]}`)
+	// Output:
+	// Passed!
+	// file:3: JSON mismatch (3 differences).
+	// /tags/2: "c" != (missing)
+	// /tags/3: "a" != (missing)
+	// /tags/2: (missing) != "d"
+}
+
+func ExampleAssertableJSON_At_pass() {
+	document := For(t).ThatActualJSON(`{"users": [{"name": "Ann"}, {"name": "Bob", "tags": {"x-y": 1, "*": 2}}]}`)
+	cases := []struct {
+		path     string
+		expected interface{}
+	}{
+		{"$", `{"users": [{"name": "Ann"}, {"name": "Bob", "tags": {"x-y": 1, "*": 2}}]}`},
+		{"$.users[0].name", `"Ann"`},
+		{"$.users[-1]['tags'][\"x-y\"]", `1`},
+		{"$.users[-1].tags['*']", `2`},
+		{"$.users[*].name", `["Ann", "Bob"]`},
+		{"$.users.*.name", `["Ann", "Bob"]`},
+	}
+
+	for _, c := range cases {
+		if document.At(c.path).Equals(c.expected).Passed() {
+			fmt.Println("Passed: " + c.path)
+		}
+	}
+	// Output:
+	// Passed: $
+	// Passed: $.users[0].name
+	// Passed: $.users[-1]['tags']["x-y"]
+	// Passed: $.users[-1].tags['*']
+	// Passed: $.users[*].name
+	// Passed: $.users.*.name
+}
+
+func ExampleAssertableJSON_At_fail() {
+	document := mockTestContextToAssert().ThatActualJSON(`{"users": [{"name": "Ann", "age": 42}, {"name": "Bob"}]}`)
+	cases := []struct {
+		path     string
+		expected interface{}
+	}{
+		{"$.users[0]", map[string]interface{}{"name": "Bob", "age": 42}},
+		{"$.users[*].name", []string{"Ann", "Cy", "Dan"}},
+		{"$.users[*]", "Ann"},
+		{"$.users[2]", nil},
+		{"$.users[0]['*']", nil},
+		{"users", nil},
+		{"$..name", nil},
+	}
+
+	for _, c := range cases {
+		document.At(c.path).EqualsValue(c.expected)
+	}
+	// Output:
+	// file:3: JSON mismatch (1 difference).
+	// /users/0/name: "Ann" != "Bob"
+	// file:3: JSON mismatch (2 differences).
+	// /users/1/name: "Bob" != "Cy"
+	// (match 3): (missing) != "Dan"
+	// file:3: JSON mismatch (1 difference).
+	// (matches): [{"age":42,"name":"Ann"},{"name":"Bob"}] != "Ann"
+	// file:3: No value at path $.users[2]
+	// file:3: No value at path $.users[0]['*']
+	// file:3: Invalid JSON path.
+	// Error: JSON path users does not start with $
+	// file:3: Invalid JSON path.
+	// Error: JSON path $..name has unsupported recursive descent
+}
+
+func ExampleAssertableJSON_At_wildcard() {
+	document := mockTestContextToAssert().ThatActualJSON(`{"users": [{"name": "Ann"}, {"name": "bob"}, {"name": 7}]}`)
+	names := document.At("$.users[*].name")
+	names.MatchesSchema("testdata/names.schema.json")
+	names.IgnoringArrayOrder().Equals(`["bob", "Ann", "Cy"]`)
+	names.At("$[1]").Equals(`"Bob"`)
+	// Output:
+	// file:3: JSON schema mismatch (3 violations).
+	// Schema: testdata/names.schema.json
+	// (matches): must have at most 2 items (maxItems)
+	// /users/1/name: must match "^[A-Z]" (pattern)
+	// /users/2/name: must be of type string but is integer (type)
+	// file:3: JSON mismatch (2 differences).
+	// /users/2/name: 7 != (missing)
+	// (match 3): (missing) != "Cy"
+	// file:3: JSON mismatch (1 difference).
+	// /users/1/name: "bob" != "Bob"
+}
+
+func ExampleAssertableJSON_MatchesSchema_pass() {
+	document := `{"users": [
+		{"name": "Ann", "age": 42, "role": "admin", "email": null, "id": 1},
+		{"name": "Bob", "age": 0, "email": "bob@pp.io", "id": "b", "tags": ["x"], "nickname": "Bobby"}
+	]}`
+
+	if For(t).ThatActualJSON(document).MatchesSchema("testdata/users.schema.json").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableJSON_MatchesSchema_fail() {
+	document := `{"users": [
+		{"name": "ann", "age": 150, "role": "guest", "email": "ann", "id": 1.5, "tags": ["", "a", "b"]},
+		{"name": "Bartholomew", "age": -1.5, "nickname": 7},
+		{"age": 1}
+	], "total": 3}`
+
+	if !mockTestContextToAssert().ThatActualJSON(document).MatchesSchema("testdata/users.schema.json").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: JSON schema mismatch (13 violations).
+	// Schema: testdata/users.schema.json
+	// /total: is not allowed (additionalProperties)
+	// /users/0/age: must be < 150 (exclusiveMaximum)
+	// /users/0/email: must match at least one schema (anyOf)
+	// /users/0/id: must match exactly one schema but matches 0 (oneOf)
+	// /users/0/name: must match "^[A-Z]" (pattern)
+	// /users/0/role: must be one of ["admin","member"] (enum)
+	// /users/0/tags: must have at most 2 items (maxItems)
+	// /users/0/tags/0: must not match schema (not)
+	// /users/1/age: must be of type integer but is number (type)
+	// /users/1/age: must be >= 0 (minimum)
+	// /users/1/name: must be at most 8 characters long (maxLength)
+	// /users/1/nickname: must be of type string but is integer (type)
+	// /users/2: must have property "name" (required)
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableJSON_MatchesSchema_invalidSchema() {
+	if !mockTestContextToAssert().ThatActualJSON(`{}`).MatchesSchema("testdata/missing.schema.json").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Invalid JSON schema.
+	// File: testdata/missing.schema.json
+	// Error: open testdata/missing.schema.json: no such file or directory
+	// Assertion failed successfully!
+}
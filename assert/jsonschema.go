@@ -0,0 +1,323 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxJSONSchemaReferenceDepth = 64
+
+// schemaDefinition is a decoded JSON Schema object.
+type schemaDefinition map[string]interface{}
+
+// jsonSchema validates decoded JSON values against a decoded JSON Schema;
+// see AssertableJSON.MatchesSchema for the supported keywords.
+type jsonSchema struct {
+	root          interface{}
+	definition    interface{}
+	matchPointers []string // the pointers of the matches if a wildcard selection is validated
+}
+
+func (schema *jsonSchema) validate(pointer string, value interface{}) []string {
+	return schema.validateAgainst(schema.definition, pointer, value, 0)
+}
+
+func (schema *jsonSchema) validateAgainst(
+	definition interface{}, pointer string, value interface{}, depth int) []string {
+
+	switch typed := definition.(type) {
+	case bool:
+		if !typed {
+			return []string{violation(pointer, "is not allowed (false schema)")}
+		}
+		return nil
+	case map[string]interface{}:
+		if reference, ok := typed["$ref"].(string); ok {
+			return schema.validateReference(reference, pointer, value, depth)
+		}
+		violations := []string{}
+		for _, validate := range []func(schemaDefinition, string, interface{}, int) []string{
+			schema.validateType, schema.validateEnumeration, schema.validateObject, schema.validateArray,
+			schema.validateNumber, schema.validateString, schema.validateComposition,
+		} {
+			violations = append(violations, validate(schemaDefinition(typed), pointer, value, depth)...)
+		}
+		return violations
+	}
+	return []string{violation(pointer, "cannot be validated against invalid schema %s", formatJSONValue(definition))}
+}
+
+func (schema *jsonSchema) validateReference(reference, pointer string, value interface{}, depth int) []string {
+	if depth >= maxJSONSchemaReferenceDepth {
+		return []string{violation(pointer, "cannot be validated; $ref %s nests too deep", reference)}
+	}
+	if !strings.HasPrefix(reference, "#") {
+		return []string{violation(pointer, "cannot be validated; $ref %s is not local", reference)}
+	}
+
+	definition := schema.root
+	for _, token := range strings.Split(reference[1:], "/")[1:] {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		object, ok := definition.(map[string]interface{})
+		if !ok {
+			definition = nil
+			break
+		}
+		definition = object[token]
+	}
+	if definition == nil {
+		return []string{violation(pointer, "cannot be validated; $ref %s is not found", reference)}
+	}
+	return schema.validateAgainst(definition, pointer, value, depth+1)
+}
+
+func (schema *jsonSchema) validateType(definition schemaDefinition, pointer string, value interface{}, _ int) []string {
+	var allowedTypes []string
+	switch typed := definition["type"].(type) {
+	case string:
+		allowedTypes = []string{typed}
+	case []interface{}:
+		for _, allowedType := range typed {
+			allowedTypes = append(allowedTypes, fmt.Sprint(allowedType))
+		}
+	default:
+		return nil
+	}
+
+	actualType := jsonTypeOf(value)
+	for _, allowedType := range allowedTypes {
+		if allowedType == actualType || allowedType == "number" && actualType == "integer" {
+			return nil
+		}
+	}
+	return []string{
+		violation(pointer, "must be of type %s but is %s (type)", strings.Join(allowedTypes, " or "), actualType)}
+}
+
+func (schema *jsonSchema) validateEnumeration(
+	definition schemaDefinition, pointer string, value interface{}, _ int) []string {
+
+	violations := []string{}
+	if constant, ok := definition["const"]; ok && len(diffJSON("", value, constant, false)) > 0 {
+		violations = append(violations, violation(pointer, "must be %s (const)", formatJSONValue(constant)))
+	}
+	if enumeration, ok := definition["enum"].([]interface{}); ok {
+		for _, allowed := range enumeration {
+			if len(diffJSON("", value, allowed, false)) == 0 {
+				return violations
+			}
+		}
+		violations = append(violations, violation(pointer, "must be one of %s (enum)", formatJSONValue(enumeration)))
+	}
+	return violations
+}
+
+func (schema *jsonSchema) validateObject(
+	definition schemaDefinition, pointer string, value interface{}, depth int) []string {
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	violations := []string{}
+	if required, ok := definition["required"].([]interface{}); ok {
+		for _, key := range required {
+			if _, ok := object[fmt.Sprint(key)]; !ok {
+				violations = append(violations, violation(pointer, "must have property %q (required)", key))
+			}
+		}
+	}
+
+	properties, _ := definition["properties"].(map[string]interface{})
+	additionalProperties, hasAdditionalProperties := definition["additionalProperties"]
+	for _, key := range unionOfJSONKeys(object, nil) {
+		propertyPointer := pointer + "/" + escapeJSONPointerToken(key)
+		if property, ok := properties[key]; ok {
+			violations = append(violations, schema.validateAgainst(property, propertyPointer, object[key], depth)...)
+		} else if hasAdditionalProperties {
+			if allowed, ok := additionalProperties.(bool); ok && !allowed {
+				violations = append(violations, violation(propertyPointer, "is not allowed (additionalProperties)"))
+			} else {
+				violations = append(violations,
+					schema.validateAgainst(additionalProperties, propertyPointer, object[key], depth)...)
+			}
+		}
+	}
+	return violations
+}
+
+func (schema *jsonSchema) validateArray(
+	definition schemaDefinition, pointer string, value interface{}, depth int) []string {
+
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	violations := []string{}
+	if minimum, ok := jsonSchemaInt(definition["minItems"]); ok && len(array) < minimum {
+		violations = append(violations, violation(pointer, "must have at least %d items (minItems)", minimum))
+	}
+	if maximum, ok := jsonSchemaInt(definition["maxItems"]); ok && len(array) > maximum {
+		violations = append(violations, violation(pointer, "must have at most %d items (maxItems)", maximum))
+	}
+	if unique, _ := definition["uniqueItems"].(bool); unique {
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if len(diffJSON("", array[i], array[j], false)) == 0 {
+					violations = append(violations,
+						violation(pointer, "must have unique items; %d and %d are equal (uniqueItems)", i, j))
+				}
+			}
+		}
+	}
+
+	items, hasItems := definition["items"]
+	for i, element := range array {
+		elementPointer := jsonElementPointer(pointer, i, schema.matchPointers)
+		if tuple, ok := items.([]interface{}); ok && i < len(tuple) {
+			violations = append(violations, schema.validateAgainst(tuple[i], elementPointer, element, depth)...)
+		} else if hasItems && !ok {
+			violations = append(violations, schema.validateAgainst(items, elementPointer, element, depth)...)
+		}
+	}
+	return violations
+}
+
+func (schema *jsonSchema) validateNumber(
+	definition schemaDefinition, pointer string, value interface{}, _ int) []string {
+
+	number, ok := jsonRat(value)
+	if !ok {
+		return nil
+	}
+
+	violations := []string{}
+	for _, bound := range []struct {
+		keyword    string
+		isViolated func(comparison int) bool
+		operator   string
+	}{
+		{"minimum", func(c int) bool { return c < 0 }, ">="},
+		{"maximum", func(c int) bool { return c > 0 }, "<="},
+		{"exclusiveMinimum", func(c int) bool { return c <= 0 }, ">"},
+		{"exclusiveMaximum", func(c int) bool { return c >= 0 }, "<"},
+	} {
+		if limit, ok := jsonRat(definition[bound.keyword]); ok && bound.isViolated(number.Cmp(limit)) {
+			violations = append(violations, violation(pointer,
+				"must be %s %s (%s)", bound.operator, formatJSONValue(definition[bound.keyword]), bound.keyword))
+		}
+	}
+	return violations
+}
+
+func (schema *jsonSchema) validateString(
+	definition schemaDefinition, pointer string, value interface{}, _ int) []string {
+
+	text, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	violations := []string{}
+	length := utf8.RuneCountInString(text)
+	if minimum, ok := jsonSchemaInt(definition["minLength"]); ok && length < minimum {
+		violations = append(violations, violation(pointer, "must be at least %d characters long (minLength)", minimum))
+	}
+	if maximum, ok := jsonSchemaInt(definition["maxLength"]); ok && length > maximum {
+		violations = append(violations, violation(pointer, "must be at most %d characters long (maxLength)", maximum))
+	}
+	if pattern, ok := definition["pattern"].(string); ok {
+		if expression, err := regexp.Compile(pattern); err != nil {
+			violations = append(violations, violation(pointer, "cannot be matched; %v (pattern)", err))
+		} else if !expression.MatchString(text) {
+			violations = append(violations, violation(pointer, "must match %q (pattern)", pattern))
+		}
+	}
+	return violations
+}
+
+func (schema *jsonSchema) validateComposition(
+	definition schemaDefinition, pointer string, value interface{}, depth int) []string {
+
+	violations := []string{}
+	if schemas, ok := definition["allOf"].([]interface{}); ok {
+		for _, subschema := range schemas {
+			violations = append(violations, schema.validateAgainst(subschema, pointer, value, depth)...)
+		}
+	}
+	if schemas, ok := definition["anyOf"].([]interface{}); ok {
+		if matches := schema.countMatches(schemas, pointer, value, depth); matches == 0 {
+			violations = append(violations, violation(pointer, "must match at least one schema (anyOf)"))
+		}
+	}
+	if schemas, ok := definition["oneOf"].([]interface{}); ok {
+		if matches := schema.countMatches(schemas, pointer, value, depth); matches != 1 {
+			violations = append(violations,
+				violation(pointer, "must match exactly one schema but matches %d (oneOf)", matches))
+		}
+	}
+	if subschema, ok := definition["not"]; ok && len(schema.validateAgainst(subschema, pointer, value, depth)) == 0 {
+		violations = append(violations, violation(pointer, "must not match schema (not)"))
+	}
+	return violations
+}
+
+func (schema *jsonSchema) countMatches(schemas []interface{}, pointer string, value interface{}, depth int) int {
+	matches := 0
+	for _, subschema := range schemas {
+		if len(schema.validateAgainst(subschema, pointer, value, depth)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if number, ok := jsonRat(typed); ok && number.IsInt() {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func jsonRat(value interface{}) (*big.Rat, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(string(number))
+}
+
+func jsonSchemaInt(value interface{}) (int, bool) {
+	number, ok := jsonRat(value)
+	if !ok || !number.IsInt() {
+		return 0, false
+	}
+	return int(number.Num().Int64()), true
+}
+
+func violation(pointer, format string, args ...interface{}) string {
+	if pointer == "" {
+		pointer = jsonRootPointer
+	}
+	return pointer + ": " + fmt.Sprintf(format, args...)
+}
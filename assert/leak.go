@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const maxGoroutineSettleBackoff = 100 * time.Millisecond
+
+// goroutineSettleTimeout is how long leak detection waits for goroutines
+// that were started by a test or a call to exit before it reports them.
+var goroutineSettleTimeout = time.Second
+
+func (testContext *testContext) VerifyNoGoroutineLeaks(ignoredTopFunctions ...string) {
+	testContext.Helper()
+	file, line := testContext.location() // must be set here to capture the right stack frame
+	before := goroutineIDs()
+	testContext.Cleanup(func() {
+		testContext.Helper()
+		if leaked := leakedGoroutines(before, ignoredTopFunctions); len(leaked) > 0 {
+			testContext.errorf(file, line, "%s", formatLeakedGoroutines(leaked))
+		}
+	})
+}
+
+func (callable *assertableCall) DoesNotLeakGoroutines(ignoredTopFunctions ...string) ValueAssertionResult {
+	callable.testContext.Helper()
+	before := goroutineIDs()
+	if outcome := recoverCall(callable.call); outcome.hasPanicked {
+		callable.testContext.decoratedErrorf("Function call panicked unexpectedly.\nPanic: %v\n%s",
+			outcome.recovered, outcome.formatStack())
+		return callable.testContext.resultOf(false, outcome.recovered, nil)
+	}
+
+	leaked := leakedGoroutines(before, ignoredTopFunctions)
+	if len(leaked) > 0 {
+		callable.testContext.decoratedErrorf("%s", formatLeakedGoroutines(leaked))
+	}
+	return callable.testContext.resultOf(len(leaked) == 0, len(leaked), 0)
+}
+
+func goroutineIDs() map[int]bool {
+	ids := map[int]bool{}
+	for _, stack := range allGoroutineStacks() {
+		ids[stack.id] = true
+	}
+	return ids
+}
+
+// leakedGoroutines waits, with exponential backoff, for the goroutines that
+// were started after the goroutines whose IDs are specified to exit, and
+// returns the stacks of the ones that don't, except for the calling
+// goroutine and the goroutines whose top functions are ignored.
+func leakedGoroutines(before map[int]bool, ignoredTopFunctions []string) []*goroutineStack {
+	self, deadline := currentGoroutineID(), time.Now().Add(goroutineSettleTimeout)
+	for backoff := time.Millisecond; ; backoff *= 2 {
+		leaked := []*goroutineStack{}
+		for _, stack := range allGoroutineStacks() {
+			if !before[stack.id] && stack.id != self && !isIgnoredGoroutine(stack, ignoredTopFunctions) {
+				leaked = append(leaked, stack)
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if len(leaked) == 0 || remaining <= 0 {
+			return leaked
+		} else if backoff > maxGoroutineSettleBackoff {
+			backoff = maxGoroutineSettleBackoff
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		time.Sleep(backoff)
+	}
+}
+
+func isIgnoredGoroutine(stack *goroutineStack, ignoredTopFunctions []string) bool {
+	for _, function := range ignoredTopFunctions {
+		if stack.topFunction == function {
+			return true
+		}
+	}
+	return false
+}
+
+// formatLeakedGoroutines formats the stacks of the specified leaked
+// goroutines grouped by where they were created, in order of creation.
+func formatLeakedGoroutines(leaked []*goroutineStack) string {
+	sort.Slice(leaked, func(i, j int) bool { return leaked[i].id < leaked[j].id })
+	sites, groups := []string{}, map[string][]*goroutineStack{}
+	for _, stack := range leaked {
+		site := stack.createdBy
+		if site == "" {
+			site = "an unknown function"
+		}
+		if _, ok := groups[site]; !ok {
+			sites = append(sites, site)
+		}
+		groups[site] = append(groups[site], stack)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Goroutine leak (%d leaked %s).\n", len(leaked), pluralize(len(leaked), "goroutine"))
+	for _, site := range sites {
+		count := len(groups[site])
+		fmt.Fprintf(&builder, "Created by %s (%d %s):\n%s", site, count, pluralize(count, "goroutine"),
+			formatGoroutineStacks(groups[site]))
+	}
+	return builder.String()
+}
@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleAssertableCall_DoesNotLeakGoroutines_pass() {
+	closeClient := func() {
+		done := make(chan struct{})
+		go func() { time.Sleep(time.Millisecond); close(done) }() // exits soon after the call returns
+	}
+
+	if For(t).ThatCalling(closeClient).DoesNotLeakGoroutines().Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func TestDoesNotLeakGoroutinesReportsLeakedGoroutinesGroupedByCreationSite(t *testing.T) {
+	defer withGoroutineSettleTimeout(10 * time.Millisecond)()
+	release := make(chan struct{})
+	defer close(release)
+
+	recorder := &logRecorder{TB: t}
+	result := For(recorder).ThatCalling(func() {
+		startBlockedGoroutines(release, 2)
+		go blockUntilClosed(release)
+	}).DoesNotLeakGoroutines()
+
+	For(t).ThatActual(result.Passed()).IsFalse()
+	if For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		message := recorder.logs[0]
+		For(t).ThatActual(strings.HasPrefix(message, "Goroutine leak (3 leaked goroutines).\n"+
+			"Created by github.com/voicera/tester/assert.startBlockedGoroutines at ")).IsTrue()
+		For(t).ThatActual(strings.Contains(message, "leak_test.go:")).IsTrue()
+		For(t).ThatActual(strings.Contains(message, " (2 goroutines):\ngoroutine ")).IsTrue()
+		For(t).ThatActual(strings.Contains(message, "\nCreated by github.com/voicera/tester/assert."+
+			"TestDoesNotLeakGoroutinesReportsLeakedGoroutinesGroupedByCreationSite.func1 at ")).IsTrue()
+		For(t).ThatActual(strings.Contains(message, " (1 goroutine):\ngoroutine ")).IsTrue()
+	}
+}
+
+func TestDoesNotLeakGoroutinesIgnoresTopFunctions(t *testing.T) {
+	defer withGoroutineSettleTimeout(10 * time.Millisecond)()
+	release := make(chan struct{})
+	defer close(release)
+
+	For(t).ThatCalling(func() { startBlockedGoroutines(release, 2) }).
+		DoesNotLeakGoroutines("github.com/voicera/tester/assert.blockUntilClosed")
+}
+
+func TestVerifyNoGoroutineLeaks(t *testing.T) {
+	defer withGoroutineSettleTimeout(10 * time.Millisecond)()
+	release := make(chan struct{})
+	defer close(release)
+
+	passing, leaking := &logRecorder{}, &logRecorder{}
+	t.Run("passing", func(t *testing.T) {
+		passing.TB = t
+		For(passing).VerifyNoGoroutineLeaks()
+		done := make(chan struct{})
+		go close(done)
+		<-done
+	})
+	t.Run("leaking", func(t *testing.T) {
+		leaking.TB = t
+		For(leaking).VerifyNoGoroutineLeaks()
+		startBlockedGoroutines(release, 1)
+	})
+
+	For(t).ThatActual(passing.failed).IsFalse()
+	For(t).ThatActual(leaking.failed).IsTrue()
+	if For(t).ThatActualCollection(leaking.logs).HasLength(1).Passed() {
+		For(t).ThatActual(strings.HasPrefix(leaking.logs[0], "Goroutine leak (1 leaked goroutine).\n"+
+			"Created by github.com/voicera/tester/assert.startBlockedGoroutines at ")).IsTrue()
+	}
+}
+
+func startBlockedGoroutines(release chan struct{}, count int) {
+	for i := 0; i < count; i++ {
+		go blockUntilClosed(release)
+	}
+}
+
+// withGoroutineSettleTimeout sets the goroutine settle timeout to the specified
+// one and returns a function that restores it.
+func withGoroutineSettleTimeout(timeout time.Duration) func() {
+	original := goroutineSettleTimeout
+	goroutineSettleTimeout = timeout
+	return func() { goroutineSettleTimeout = original }
+}
@@ -0,0 +1,243 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// lineDiffContext is the number of unchanged lines around changes.
+	lineDiffContext = 3
+
+	// maxLineDiffCells caps the size of the table that matches lines; beyond
+	// that, the differing lines are reported as replaced rather than matched.
+	maxLineDiffCells = 1 << 22
+
+	visibleLegend = "Legend: → tab, · trailing space, ␍ carriage return, [U+XXXX] invisible character\n"
+)
+
+// lineEdit is a line of a unified diff: an unchanged line (' '), a line of
+// the actual string only ('-'), or a line of the expected string only ('+').
+type lineEdit struct {
+	kind                         byte
+	line                         string // ends with "\n" unless it's the last line
+	actualNumber, expectedNumber int    // the 1-based numbers of the line in the strings that have it
+}
+
+// formatLineDiff formats a unified diff, with context, of the lines of the
+// specified actual and expected strings, followed by the location of the
+// first differing rune; for example:
+//     --- Actual
+//     +++ Expected
+//     @@ -1,3 +1,3 @@
+//      Dear Ann,
+//     -Your order has shipped.
+//     +Your order has been delivered.
+//      Regards
+//     First difference: line 2, column 16; actual 's' (U+0073), expected 'b' (U+0062)
+// Invisible differences are made visible as the legend describes; the legend
+// is included only if needed.
+func formatLineDiff(actual, expected string) string {
+	var builder strings.Builder
+	builder.WriteString("--- Actual\n+++ Expected\n")
+	hasInvisibles := false
+	for _, hunk := range lineDiffHunks(diffLines(splitLines(actual), splitLines(expected))) {
+		builder.WriteString(hunk.header())
+		for _, edit := range hunk {
+			line, isVisualized := visualizeLine(edit.line)
+			hasInvisibles = hasInvisibles || isVisualized
+			fmt.Fprintf(&builder, "%c%s\n", edit.kind, line)
+			if !strings.HasSuffix(edit.line, "\n") {
+				builder.WriteString("\\ No newline at end of string\n")
+			}
+		}
+	}
+	if hasInvisibles {
+		builder.WriteString(visibleLegend)
+	}
+	builder.WriteString(describeFirstDifference(actual, expected))
+	return builder.String()
+}
+
+func splitLines(value string) []string {
+	lines := strings.SplitAfter(value, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines matches the specified lines by their longest common subsequence
+// and returns the edits that turn the actual lines into the expected ones.
+func diffLines(actual, expected []string) []*lineEdit {
+	prefix := 0
+	for prefix < len(actual) && prefix < len(expected) && actual[prefix] == expected[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(actual)-prefix && suffix < len(expected)-prefix &&
+		actual[len(actual)-1-suffix] == expected[len(expected)-1-suffix] {
+		suffix++
+	}
+
+	edits := []*lineEdit{}
+	unchanged := func(i, j int) {
+		edits = append(edits, &lineEdit{kind: ' ', line: actual[i], actualNumber: i + 1, expectedNumber: j + 1})
+	}
+	for i := 0; i < prefix; i++ {
+		unchanged(i, i)
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of the
+	// middle actual lines from i on and the middle expected lines from j on.
+	a, b := actual[prefix:len(actual)-suffix], expected[prefix:len(expected)-suffix]
+	lengths := make([][]int, len(a)+1)
+	if (len(a)+1)*(len(b)+1) <= maxLineDiffCells {
+		for i := range lengths {
+			lengths[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lengths[i][j] = lengths[i+1][j+1] + 1
+				} else if lengths[i+1][j] >= lengths[i][j+1] {
+					lengths[i][j] = lengths[i+1][j]
+				} else {
+					lengths[i][j] = lengths[i][j+1]
+				}
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j] && lengths[i] != nil:
+			unchanged(prefix+i, prefix+j)
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lengths[i] == nil || lengths[i+1][j] >= lengths[i][j+1]):
+			edits = append(edits, &lineEdit{kind: '-', line: a[i], actualNumber: prefix + i + 1})
+			i++
+		default:
+			edits = append(edits, &lineEdit{kind: '+', line: b[j], expectedNumber: prefix + j + 1})
+			j++
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		unchanged(len(actual)-k, len(expected)-k)
+	}
+	return edits
+}
+
+// lineDiffHunk is a run of edits that includes changes and their context.
+type lineDiffHunk []*lineEdit
+
+// lineDiffHunks groups the changes of the specified edits into hunks,
+// merging the ones whose contexts overlap.
+func lineDiffHunks(edits []*lineEdit) []lineDiffHunk {
+	hunks := []lineDiffHunk{}
+	start, end := -1, -1 // of the current hunk, exclusive of the end
+	for i, edit := range edits {
+		if edit.kind == ' ' {
+			continue
+		}
+		if start >= 0 && i-lineDiffContext > end {
+			hunks = append(hunks, edits[start:end])
+			start = -1
+		}
+		if start < 0 {
+			start = max(0, i-lineDiffContext)
+		}
+		end = min(len(edits), i+lineDiffContext+1)
+	}
+	if start >= 0 {
+		hunks = append(hunks, edits[start:end])
+	}
+	return hunks
+}
+
+// header returns the header of the hunk in the unified format; e.g.,
+// "@@ -12,7 +12,6 @@". A string without lines in the hunk is empty, as the
+// hunk would include context otherwise, hence its start is 0.
+func (hunk lineDiffHunk) header() string {
+	actualStart, actualCount, expectedStart, expectedCount := 0, 0, 0, 0
+	for _, edit := range hunk {
+		if edit.kind != '+' {
+			if actualCount == 0 {
+				actualStart = edit.actualNumber
+			}
+			actualCount++
+		}
+		if edit.kind != '-' {
+			if expectedCount == 0 {
+				expectedStart = edit.expectedNumber
+			}
+			expectedCount++
+		}
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", actualStart, actualCount, expectedStart, expectedCount)
+}
+
+// visualizeLine returns the specified line, sans its line feed, with its
+// invisible characters made visible per visibleLegend, and whether any was.
+func visualizeLine(line string) (string, bool) {
+	content := strings.TrimSuffix(line, "\n")
+	content, hasCarriageReturn := strings.CutSuffix(content, "\r")
+	trimmed := strings.TrimRight(content, " ")
+
+	var builder strings.Builder
+	for _, r := range trimmed {
+		switch {
+		case r == '\t':
+			builder.WriteString("→")
+		case isInvisible(r):
+			fmt.Fprintf(&builder, "[%U]", r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteString(strings.Repeat("·", len(content)-len(trimmed)))
+	if hasCarriageReturn {
+		builder.WriteString("␍")
+	}
+	return builder.String(), builder.String() != content
+}
+
+// isInvisible returns whether the specified rune, other than a space or a
+// tab, renders as blank or not at all; e.g., a no-break space (U+00A0) or a
+// zero-width space (U+200B).
+func isInvisible(r rune) bool {
+	return r != ' ' && r != '\t' && (unicode.IsSpace(r) || unicode.IsControl(r) || unicode.Is(unicode.Cf, r) ||
+		unicode.Is(unicode.Zs, r))
+}
+
+// describeFirstDifference locates the first rune in which the specified
+// strings differ by its line and column in the actual string, and describes
+// the differing runes.
+func describeFirstDifference(actual, expected string) string {
+	line, column, i := 1, 1, 0
+	for i < len(actual) && i < len(expected) {
+		actualRune, size := utf8.DecodeRuneInString(actual[i:])
+		if expectedRune, _ := utf8.DecodeRuneInString(expected[i:]); actualRune != expectedRune {
+			break
+		}
+		if i += size; actualRune == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return fmt.Sprintf("First difference: line %d, column %d; actual %s, expected %s\n",
+		line, column, describeRuneAt(actual, i), describeRuneAt(expected, i))
+}
+
+func describeRuneAt(value string, i int) string {
+	if i >= len(value) {
+		return "end of string"
+	}
+	r, _ := utf8.DecodeRuneInString(value[i:])
+	return fmt.Sprintf("%q (%U)", r, r)
+}
@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatLineDiff(t *testing.T) {
+	lines := func(values ...string) string { return strings.Join(values, "\n") + "\n" }
+	cases := []struct {
+		id               string
+		actual, expected string
+		diff             string
+	}{
+		{"separate hunks",
+			lines("1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"),
+			lines("1", "two", "3", "4", "5", "6", "7", "8", "9", "10", "eleven", "12"),
+			"--- Actual\n+++ Expected\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -8,5 +8,5 @@\n 8\n 9\n 10\n-11\n+eleven\n 12\n" +
+				"First difference: line 2, column 1; actual '2' (U+0032), expected 't' (U+0074)\n"},
+		{"inserted and deleted lines",
+			lines("a", "b", "c", "d"),
+			lines("a", "c", "d", "e"),
+			"--- Actual\n+++ Expected\n@@ -1,4 +1,4 @@\n a\n-b\n c\n d\n+e\n" +
+				"First difference: line 2, column 1; actual 'b' (U+0062), expected 'c' (U+0063)\n"},
+		{"no newline at end",
+			"a\nb",
+			"a\nb\n",
+			"--- Actual\n+++ Expected\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of string\n+b\n" +
+				"First difference: line 2, column 2; actual end of string, expected '\\n' (U+000A)\n"},
+		{"empty actual",
+			"",
+			lines("a", "b"),
+			"--- Actual\n+++ Expected\n@@ -0,0 +1,2 @@\n+a\n+b\n" +
+				"First difference: line 1, column 1; actual end of string, expected 'a' (U+0061)\n"},
+		{"zero-width space",
+			lines("id", "x\u200by"),
+			lines("id", "xy"),
+			"--- Actual\n+++ Expected\n@@ -1,2 +1,2 @@\n id\n-x[U+200B]y\n+xy\n" + visibleLegend +
+				"First difference: line 2, column 2; actual '\\u200b' (U+200B), expected 'y' (U+0079)\n"},
+	}
+
+	for _, c := range cases {
+		For(t, c.id).ThatActual(formatLineDiff(c.actual, c.expected)).Equals(c.diff)
+	}
+}
+
+func TestDiffLinesFallsBackToReplacingLinesIfTooMany(t *testing.T) {
+	actual, expected := make([]string, 3000), make([]string, 3000)
+	for i := range actual {
+		actual[i], expected[i] = "same\n", "same\n"
+	}
+	actual[0], expected[len(expected)-1] = "first\n", "last\n"
+
+	edits := diffLines(actual, expected)
+	For(t).ThatActual(len(edits)).Equals(6000)
+	For(t).ThatActual(edits[0].kind).Equals(byte('-'))
+	For(t).ThatActual(edits[5999].kind).Equals(byte('+'))
+}
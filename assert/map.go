@@ -0,0 +1,294 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// AssertableMap represents an under-test map that's expected to meet
+// certain criteria. Values are compared using reflect.DeepEqual.
+type AssertableMap interface {
+	// Equals asserts that the specified actual map has the same entries as
+	// the expected one. On failure, missing keys, extra keys, and keys whose
+	// values differ are listed separately.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Equals(expected interface{}) ValueAssertionResult
+
+	// HasKey asserts that the specified actual map has the expected key.
+	// Numeric keys are converted to the map's key type if their values fit
+	// exactly (e.g., 1 is looked up as int64(1) in a map[int64]int); keys
+	// that can't be converted or can't be map keys (e.g., slices) fail the
+	// assertion, as they do for DoesNotHaveKey, HasEntry, and HasKeys.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasKey(key interface{}) ValueAssertionResult
+
+	// DoesNotHaveKey asserts that the specified actual map does not have
+	// the unexpected key.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	DoesNotHaveKey(key interface{}) ValueAssertionResult
+
+	// HasEntry asserts that the specified actual map maps the specified key
+	// to the expected value.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasEntry(key, value interface{}) ValueAssertionResult
+
+	// HasKeys asserts that the specified actual map has all the expected keys,
+	// among other keys (if any).
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasKeys(keys ...interface{}) ValueAssertionResult
+
+	// ContainsEntriesOf asserts that the specified actual map contains all
+	// the entries of the specified subset map, among other entries (if any).
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsEntriesOf(subset interface{}) ValueAssertionResult
+}
+
+type assertableMap struct {
+	testContext *testContext
+	value       interface{}
+}
+
+// mapDifference describes how an actual map differs from an expected one.
+type mapDifference struct {
+	missingKeys   []interface{}
+	extraKeys     []interface{}
+	differentKeys []interface{}
+	actual        reflect.Value
+	expected      reflect.Value
+}
+
+func (actual *assertableMap) Equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareEntries(expected, true)
+}
+
+func (actual *assertableMap) HasKey(key interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.HasKeys(key)
+}
+
+func (actual *assertableMap) DoesNotHaveKey(key interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	value, isMap := actual.reflectMap()
+	if !isMap {
+		return actual.unsupportedMapResult(&anyOtherValue{})
+	} else if !actual.haveKeyType(value, key) {
+		return actual.testContext.resultOf(false, actual.value, &anyOtherValue{})
+	}
+	_, hasKey := mapIndex(value, key)
+	if hasKey {
+		actual.testContext.decoratedErrorf("Map has unexpected key.\nKey: %#v\nActual: %#v\n", key, actual.value)
+	}
+	return actual.testContext.resultOf(!hasKey, actual.value, &anyOtherValue{})
+}
+
+func (actual *assertableMap) HasEntry(key, value interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualMap, isMap := actual.reflectMap()
+	if !isMap {
+		return actual.unsupportedMapResult(value)
+	} else if !actual.haveKeyType(actualMap, key) {
+		return actual.testContext.resultOf(false, actual.value, value)
+	}
+
+	actualValue, hasKey := mapIndex(actualMap, key)
+	passed := hasKey && reflect.DeepEqual(actualValue, value)
+	if !hasKey {
+		actual.testContext.decoratedErrorf("Map mismatch.\nMissing keys: %s\n", formatElements([]interface{}{key}))
+	} else if !passed {
+		actual.testContext.decoratedErrorf("Map mismatch.\nDifferent values:\n%s",
+			formatDifferentMapValues(key, actualValue, value))
+	}
+	return actual.testContext.resultOf(passed, actual.value, map[interface{}]interface{}{key: value})
+}
+
+func (actual *assertableMap) HasKeys(keys ...interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	value, isMap := actual.reflectMap()
+	if !isMap {
+		return actual.unsupportedMapResult(keys)
+	} else if !actual.haveKeyType(value, keys...) {
+		return actual.testContext.resultOf(false, actual.value, keys)
+	}
+	missingKeys := []interface{}{}
+	for _, key := range keys {
+		if _, hasKey := mapIndex(value, key); !hasKey {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	if len(missingKeys) > 0 {
+		actual.testContext.decoratedErrorf(
+			"Map is missing keys.\nMissing keys: %s\nActual keys: %s\n",
+			formatElements(missingKeys), formatElements(sortedMapKeys(value)))
+	}
+	return actual.testContext.resultOf(len(missingKeys) == 0, actual.value, keys)
+}
+
+func (actual *assertableMap) ContainsEntriesOf(subset interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareEntries(subset, false)
+}
+
+func (actual *assertableMap) compareEntries(expected interface{}, isExtraKeyMismatch bool) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualValue, isMap := actual.reflectMap()
+	expectedValue := reflect.ValueOf(expected)
+	if !isMap || expectedValue.Kind() != reflect.Map {
+		return actual.unsupportedMapResult(expected)
+	}
+
+	difference := diffMaps(actualValue, expectedValue)
+	if !isExtraKeyMismatch {
+		difference.extraKeys = nil
+	}
+	passed := difference.isEmpty()
+	if !passed {
+		actual.testContext.decoratedErrorf("Map mismatch.\n%s", difference)
+	}
+	return actual.testContext.resultOf(passed, actual.value, expected)
+}
+
+func (actual *assertableMap) reflectMap() (reflect.Value, bool) {
+	value := reflect.ValueOf(actual.value)
+	return value, value.Kind() == reflect.Map
+}
+
+// haveKeyType reports the specified keys that can't be looked up in the
+// specified map, either because they're unhashable or because they can't
+// be converted to its key type; it returns whether all of them can be.
+func (actual *assertableMap) haveKeyType(m reflect.Value, keys ...interface{}) bool {
+	actual.testContext.Helper()
+	for _, key := range keys {
+		if keyValue := reflect.ValueOf(key); keyValue.IsValid() && !keyValue.Comparable() {
+			actual.testContext.decoratedErrorf("Unhashable map key.\nKey: %T=%#v\n", key, key)
+			return false
+		} else if _, ok := mapKey(m, key); !ok {
+			actual.testContext.decoratedErrorf("Map key type mismatch.\nKey: %T=%#v\nKey type: %v\n",
+				key, key, m.Type().Key())
+			return false
+		}
+	}
+	return true
+}
+
+func (actual *assertableMap) unsupportedMapResult(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	actual.testContext.decoratedErrorf("Unsupported map type.\nActual: %T=%v\n", actual.value, actual.value)
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+func diffMaps(actual, expected reflect.Value) *mapDifference {
+	difference := &mapDifference{actual: actual, expected: expected}
+	for _, key := range sortedMapKeys(expected) {
+		actualValue, hasKey := mapIndex(actual, key)
+		if !hasKey {
+			difference.missingKeys = append(difference.missingKeys, key)
+		} else if expectedValue, _ := mapIndex(expected, key); !reflect.DeepEqual(actualValue, expectedValue) {
+			difference.differentKeys = append(difference.differentKeys, key)
+		}
+	}
+	for _, key := range sortedMapKeys(actual) {
+		if _, hasKey := mapIndex(expected, key); !hasKey {
+			difference.extraKeys = append(difference.extraKeys, key)
+		}
+	}
+	return difference
+}
+
+func (difference *mapDifference) isEmpty() bool {
+	return len(difference.missingKeys) == 0 && len(difference.extraKeys) == 0 && len(difference.differentKeys) == 0
+}
+
+func (difference *mapDifference) String() string {
+	var builder strings.Builder
+	if len(difference.missingKeys) > 0 {
+		fmt.Fprintf(&builder, "Missing keys: %s\n", formatElements(difference.missingKeys))
+	}
+	if len(difference.extraKeys) > 0 {
+		fmt.Fprintf(&builder, "Extra keys: %s\n", formatElements(difference.extraKeys))
+	}
+	if len(difference.differentKeys) > 0 {
+		builder.WriteString("Different values:\n")
+	}
+	for _, key := range difference.differentKeys {
+		actualValue, _ := mapIndex(difference.actual, key)
+		expectedValue, _ := mapIndex(difference.expected, key)
+		builder.WriteString(formatDifferentMapValues(key, actualValue, expectedValue))
+	}
+	return builder.String()
+}
+
+// formatDifferentMapValues formats the differences between the specified
+// values of the specified key, one indented line per difference.
+func formatDifferentMapValues(key, actual, expected interface{}) string {
+	var builder strings.Builder
+	path := fmt.Sprintf("%s[%#v]", rootPath, key)
+	for _, valueDifference := range diffValuesAt(path, actual, expected, newEqualityOptions()) {
+		fmt.Fprintf(&builder, "  %s\n", valueDifference)
+	}
+	return builder.String()
+}
+
+// mapIndex looks up the specified key in the specified map, converting
+// the key to the map's key type if need be.
+func mapIndex(m reflect.Value, key interface{}) (value interface{}, ok bool) {
+	keyValue, ok := mapKey(m, key)
+	if !ok || !keyValue.Comparable() { // e.g., an interface holding a slice, which would make MapIndex panic
+		return nil, false
+	}
+	element := m.MapIndex(keyValue)
+	if !element.IsValid() {
+		return nil, false
+	}
+	return element.Interface(), true
+}
+
+// mapKey converts the specified key to the key type of the specified map;
+// numbers are converted across kinds only if their values fit exactly
+// (e.g., 1 fits int64 and 2.0 fits int, but -1 doesn't fit uint).
+func mapKey(m reflect.Value, key interface{}) (reflect.Value, bool) {
+	keyType := m.Type().Key()
+	keyValue := reflect.ValueOf(key)
+	switch {
+	case !keyValue.IsValid():
+		switch keyType.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Chan:
+			return reflect.Zero(keyType), true
+		}
+		return keyValue, false
+	case keyValue.Type().AssignableTo(keyType):
+		return keyValue, true
+	case keyValue.Type().ConvertibleTo(keyType) && keyValue.Kind() == keyType.Kind():
+		return keyValue.Convert(keyType), true
+	case isNumericKind(keyValue.Kind()) && isNumericKind(keyType.Kind()):
+		converted := keyValue.Convert(keyType)
+		comparison, ok := compareNumbers(toNumber(key), toNumber(converted.Interface()))
+		return converted, ok && comparison == 0
+	}
+	return keyValue, false
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// sortedMapKeys returns the keys of the specified map sorted by their
+// Go-syntax representation to keep failure messages deterministic.
+func sortedMapKeys(m reflect.Value) []interface{} {
+	keys := make([]interface{}, 0, m.Len())
+	for _, key := range m.MapKeys() {
+		keys = append(keys, key.Interface())
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+	})
+	return keys
+}
@@ -0,0 +1,224 @@
+package assert
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+func ExampleAssertableMap_Equals_pass() {
+	payload := map[string]interface{}{"id": 42, "tags": []string{"foo"}}
+	if For(t).ThatActualMap(payload).Equals(map[string]interface{}{"tags": []string{"foo"}, "id": 42}).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableMap_Equals_fail() {
+	actual := map[string]interface{}{
+		"id":     42,
+		"count":  int64(1),
+		"author": &mail.Address{Name: "Richard Hendricks", Address: "richard@pp.io"},
+		"extra":  true,
+	}
+	expected := map[string]interface{}{
+		"id":      13,
+		"count":   1,
+		"author":  &mail.Address{Name: "Erlich Bachman", Address: "erlich@pp.io"},
+		"missing": "value",
+	}
+
+	if !mockTestContextToAssert().ThatActualMap(actual).Equals(expected).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Map mismatch.
+	// Missing keys: ["missing"]
+	// Extra keys: ["extra"]
+	// Different values:
+	//   Actual["author"].Name: "Richard Hendricks" != "Erlich Bachman"
+	//   Actual["author"].Address: "richard@pp.io" != "erlich@pp.io"
+	//   Actual["count"]: int64=1 != int=1
+	//   Actual["id"]: 42 != 13
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableMap_Equals_unsupportedType() {
+	if !mockTestContextToAssert().ThatActualMap([]int{42}).Equals(map[int]int{0: 42}).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Unsupported map type.
+	// Actual: []int=[42]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableMap_HasKey_pass() {
+	type key string
+	cases := []struct {
+		id     string
+		actual interface{}
+		key    interface{}
+	}{
+		{"same key type", map[string]int{"foo": 42}, "foo"},
+		{"convertible key type", map[key]int{"foo": 42}, "foo"},
+		{"interface key type", map[interface{}]int{42: 42}, 42},
+		{"nil key", map[interface{}]int{nil: 42}, nil},
+		{"numeric key of another kind", map[int64]int{1: 42}, 1},
+		{"float key with an integral value", map[uint8]int{2: 42}, 2.0},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualMap(c.actual).HasKey(c.key).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: same key type
+	// Passed: convertible key type
+	// Passed: interface key type
+	// Passed: nil key
+	// Passed: numeric key of another kind
+	// Passed: float key with an integral value
+}
+
+func ExampleAssertableMap_HasKey_fail() {
+	if !mockTestContextToAssert().ThatActualMap(map[string]int{"foo": 42}).HasKey("bar").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Map is missing keys.
+	// Missing keys: ["bar"]
+	// Actual keys: ["foo"]
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableMap_DoesNotHaveKey_pass() {
+	if For(t).ThatActualMap(map[string]int{"foo": 42}).DoesNotHaveKey("bar").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableMap_DoesNotHaveKey_fail() {
+	cases := []struct {
+		id     string
+		actual interface{}
+		key    interface{}
+	}{
+		{"same key type", map[string]int{"foo": 42}, "foo"},
+		{"numeric key of another kind", map[int64]int{1: 42}, 1},
+		{"key type mismatch", map[uint]int{1: 42}, -1},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualMap(c.actual).DoesNotHaveKey(c.key).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [same key type] Map has unexpected key.
+	// Key: "foo"
+	// Actual: map[string]int{"foo":42}
+	// Assertion failed successfully!
+	// file:3: [numeric key of another kind] Map has unexpected key.
+	// Key: 1
+	// Actual: map[int64]int{1:42}
+	// Assertion failed successfully!
+	// file:3: [key type mismatch] Map key type mismatch.
+	// Key: int=-1
+	// Key type: uint
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableMap_HasEntry_pass() {
+	if For(t).ThatActualMap(map[string]interface{}{"foo": 42, "bar": nil}).HasEntry("bar", nil).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableMap_HasEntry_fail() {
+	cases := []struct {
+		id     string
+		actual interface{}
+		key    interface{}
+		value  interface{}
+	}{
+		{"missing key", map[string]int{"foo": 42}, "bar", 42},
+		{"different value", map[string]int{"foo": 42}, "foo", 13},
+		{"unhashable key", map[interface{}]int{"foo": 42}, []string{"foo"}, 42},
+		{"unhashable field", map[interface{}]int{"foo": 42}, struct{ Key interface{} }{[]int{1}}, 42},
+		{"key type mismatch", map[int]int{1: 42}, 1.5, 42},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualMap(c.actual).HasEntry(c.key, c.value).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [missing key] Map mismatch.
+	// Missing keys: ["bar"]
+	// Assertion failed successfully!
+	// file:3: [different value] Map mismatch.
+	// Different values:
+	//   Actual["foo"]: 42 != 13
+	// Assertion failed successfully!
+	// file:3: [unhashable key] Unhashable map key.
+	// Key: []string=[]string{"foo"}
+	// Assertion failed successfully!
+	// file:3: [unhashable field] Unhashable map key.
+	// Key: struct { Key interface {} }=struct { Key interface {} }{Key:[]int{1}}
+	// Assertion failed successfully!
+	// file:3: [key type mismatch] Map key type mismatch.
+	// Key: float64=1.5
+	// Key type: int
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableMap_HasKeys_pass() {
+	if For(t).ThatActualMap(map[int]bool{1: true, 2: false, 3: true}).HasKeys(3, 1).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableMap_HasKeys_fail() {
+	if !mockTestContextToAssert().ThatActualMap(map[int]bool{1: true, 2: false}).HasKeys(1, 3, 4).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	if !mockTestContextToAssert().ThatActualMap(map[int64]bool{1: true}).HasKeys(1, "2").Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Map is missing keys.
+	// Missing keys: [3, 4]
+	// Actual keys: [1, 2]
+	// Assertion failed successfully!
+	// file:3: Map key type mismatch.
+	// Key: string="2"
+	// Key type: int64
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableMap_ContainsEntriesOf_pass() {
+	payload := map[string]interface{}{"id": 42, "name": "foo", "extra": true}
+	if For(t).ThatActualMap(payload).ContainsEntriesOf(map[string]interface{}{"id": 42, "name": "foo"}).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableMap_ContainsEntriesOf_fail() {
+	payload := map[string]interface{}{"id": 42, "name": "foo", "extra": true}
+	subset := map[string]interface{}{"id": 13, "missing": nil}
+	if !mockTestContextToAssert().ThatActualMap(payload).ContainsEntriesOf(subset).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Map mismatch.
+	// Missing keys: ["missing"]
+	// Different values:
+	//   Actual["id"]: 42 != 13
+	// Assertion failed successfully!
+}
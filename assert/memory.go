@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"testing"
+)
+
+// allocationRuns is the number of runs that allocations are averaged over.
+const allocationRuns = 100
+
+func (callable *assertableCall) AllocatesAtMost(allocations float64) ValueAssertionResult {
+	callable.testContext.Helper()
+	allocationsPerRun := testing.AllocsPerRun(allocationRuns, callable.call)
+	if allocationsPerRun > allocations {
+		callable.testContext.decoratedErrorf("Allocation budget exceeded.\nActual: %v allocs/op, %d B/op\n"+
+			"Expected: at most %v allocs/op\n%s", allocationsPerRun, bytesAllocatedPerRun(callable.call),
+			allocations, callable.writeHeapProfile())
+	}
+	return callable.testContext.resultOf(allocationsPerRun <= allocations, allocationsPerRun, allocations)
+}
+
+func (callable *assertableCall) DoesNotGrowHeapBy(bytes int64, iterations int) ValueAssertionResult {
+	callable.testContext.Helper()
+	if iterations < 1 {
+		callable.testContext.decoratedErrorf("Invalid number of iterations.\nActual: %d\nExpected: at least 1\n",
+			iterations)
+		return callable.testContext.resultOf(false, iterations, 1)
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := 0; i < iterations; i++ {
+		callable.call()
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	growth := int64(after.HeapAlloc) - int64(before.HeapAlloc)
+	if growth > bytes {
+		callable.testContext.decoratedErrorf("Heap growth budget exceeded.\nActual: %d B over %d %s, %d B/op\n"+
+			"Expected: at most %d B\n%s", growth, iterations, pluralize(iterations, "iteration"),
+			(after.TotalAlloc-before.TotalAlloc)/uint64(iterations), bytes, callable.writeHeapProfile())
+	}
+	return callable.testContext.resultOf(growth <= bytes, growth, bytes)
+}
+
+// bytesAllocatedPerRun returns the average number of bytes that the
+// specified call allocates per run.
+func bytesAllocatedPerRun(call func()) uint64 {
+	var before, after runtime.MemStats
+	call() // warms up, like testing.AllocsPerRun does
+	runtime.ReadMemStats(&before)
+	for i := 0; i < allocationRuns; i++ {
+		call()
+	}
+	runtime.ReadMemStats(&after)
+	return (after.TotalAlloc - before.TotalAlloc) / allocationRuns
+}
+
+// writeHeapProfile writes a heap profile to the directory that the
+// -tester.heap-profiles flag specifies, if any, and returns a line that
+// reports where it was written, if it was, or why it wasn't.
+func (callable *assertableCall) writeHeapProfile() string {
+	return callable.testContext.writeProfile(*heapProfilesDirectory, "Heap", func(file *os.File) error {
+		runtime.GC() // updates the profile with the latest statistics
+		return pprof.Lookup("heap").WriteTo(file, 0)
+	})
+}
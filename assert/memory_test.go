@@ -0,0 +1,91 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var (
+	allocated []byte
+	retained  [][]byte
+)
+
+func ExampleAssertableCall_AllocatesAtMost_pass() {
+	sum := func(numbers []int) (total int) {
+		for _, number := range numbers {
+			total += number
+		}
+		return total
+	}
+
+	numbers := []int{4, 8, 15, 16, 23, 42}
+	if For(t).ThatCalling(func() { sum(numbers) }).AllocatesAtMost(0).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_DoesNotGrowHeapBy_pass() {
+	if For(t).ThatCalling(func() { allocated = make([]byte, 1024) }).DoesNotGrowHeapBy(64*1024, 1000).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_DoesNotGrowHeapBy_fail() {
+	mockTestContextToAssert().ThatCalling(func() {}).DoesNotGrowHeapBy(0, 0)
+	// Output:
+	// file:3: Invalid number of iterations.
+	// Actual: 0
+	// Expected: at least 1
+}
+
+func TestMemoryBudgetFailures(t *testing.T) {
+	defer func(original string) { *heapProfilesDirectory = original }(*heapProfilesDirectory)
+	cases := []struct {
+		id              string
+		assert          func(call AssertableCall) ValueAssertionResult
+		call            func()
+		expected        string
+		profilesEnabled bool
+	}{
+		{"allocations",
+			func(call AssertableCall) ValueAssertionResult { return call.AllocatesAtMost(0) },
+			func() { allocated = make([]byte, 64) },
+			"Allocation budget exceeded.\nActual: 1 allocs/op, 64 B/op\nExpected: at most 0 allocs/op", false},
+		{"heap growth",
+			func(call AssertableCall) ValueAssertionResult { return call.DoesNotGrowHeapBy(64*1024, 1000) },
+			func() { retained = append(retained, make([]byte, 1024)) },
+			"Heap growth budget exceeded.\nActual: ", false},
+		{"allocations with heap profiles",
+			func(call AssertableCall) ValueAssertionResult { return call.AllocatesAtMost(0) },
+			func() { allocated = make([]byte, 64) },
+			"Allocation budget exceeded.\n", true},
+	}
+
+	for _, c := range cases {
+		*heapProfilesDirectory = ""
+		if c.profilesEnabled {
+			*heapProfilesDirectory = t.TempDir()
+		}
+		retained = nil
+		recorder := &logRecorder{TB: t}
+		For(t, c.id).ThatActual(c.assert(For(recorder).ThatCalling(c.call)).Passed()).IsFalse()
+		if !For(t, c.id).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+			continue
+		}
+
+		message := recorder.logs[0]
+		For(t, c.id).ThatActual(strings.HasPrefix(message, c.expected)).IsTrue()
+		_, profile, hasProfile := strings.Cut(message, "\nHeap profile: ")
+		if For(t, c.id).ThatActual(hasProfile).Equals(c.profilesEnabled).Passed() && hasProfile {
+			_, err := os.Stat(profile)
+			For(t, c.id).ThatActualError(err).IsNil()
+			expectedPrefix := filepath.Join(*heapProfilesDirectory, "TestMemoryBudgetFailures-")
+			For(t, c.id).ThatActual(strings.HasPrefix(profile, expectedPrefix)).IsTrue()
+		}
+	}
+}
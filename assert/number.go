@@ -0,0 +1,458 @@
+package assert
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// AssertableNumber represents an under-test number that's expected to meet
+// certain criteria. Numbers of any integer or floating-point kind, as well as
+// *big.Int, *big.Float, and *big.Rat, can be compared to each other exactly;
+// e.g., int64(3) equals 3.0.
+type AssertableNumber interface {
+	// Equals asserts that the specified actual number equals the expected one
+	// regardless of their types.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Equals(expected interface{}) ValueAssertionResult
+
+	// IsGreaterThan asserts that the specified actual number is greater than
+	// the specified bound.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsGreaterThan(bound interface{}) ValueAssertionResult
+
+	// IsGreaterThanOrEqualTo asserts that the specified actual number is
+	// greater than or equal to the specified bound.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsGreaterThanOrEqualTo(bound interface{}) ValueAssertionResult
+
+	// IsLessThan asserts that the specified actual number is less than
+	// the specified bound.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsLessThan(bound interface{}) ValueAssertionResult
+
+	// IsLessThanOrEqualTo asserts that the specified actual number is
+	// less than or equal to the specified bound.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsLessThanOrEqualTo(bound interface{}) ValueAssertionResult
+
+	// IsBetween asserts that the specified actual number is within
+	// the specified inclusive range, whose lower bound mustn't exceed its
+	// upper bound.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsBetween(lower, upper interface{}) ValueAssertionResult
+
+	// IsCloseTo asserts that the absolute difference between the specified
+	// actual number and the expected one is at most absDelta, which mustn't
+	// be negative.
+	// If the actual value is a slice or an array of numbers, the assertion is
+	// performed element-wise against an expected slice or array of numbers.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsCloseTo(expected, absDelta interface{}) ValueAssertionResult
+
+	// IsWithinRelative asserts that the absolute difference between
+	// the specified actual number and the expected one is at most epsilon
+	// times the larger magnitude of the two; epsilon mustn't be negative.
+	// If the actual value is a slice or an array of numbers, the assertion is
+	// performed element-wise against an expected slice or array of numbers.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsWithinRelative(expected, epsilon interface{}) ValueAssertionResult
+
+	// IsWithinULPs asserts that the specified actual number and the expected
+	// one are at most the specified, non-negative number of units in the last
+	// place apart.
+	// Numbers are compared as float32 values if the actual number (or element)
+	// is a float32; otherwise, as float64 values.
+	// If the actual value is a slice or an array of numbers, the assertion is
+	// performed element-wise against an expected slice or array of numbers.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	IsWithinULPs(expected interface{}, ulps int) ValueAssertionResult
+}
+
+type assertableNumber struct {
+	testContext *testContext
+	value       interface{}
+}
+
+// number represents any supported numeric value exactly; rat is nil for
+// infinite values and NaN.
+type number struct {
+	rat       *big.Rat
+	infinity  int
+	isNaN     bool
+	isFloat32 bool
+}
+
+// toleranceCheck measures how far an actual number deviates from an expected
+// one, and whether such deviation is tolerated.
+type toleranceCheck struct {
+	description string // formats the expected number and the tolerance
+	tolerance   interface{}
+	measure     func(actual, expected *number) (deviation float64, isTolerated bool)
+}
+
+func (actual *assertableNumber) Equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareTo(
+		expected, "Number mismatch.\nActual: %v\nExpected: %v\n", func(c int) bool { return c == 0 })
+}
+
+func (actual *assertableNumber) IsGreaterThan(bound interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareTo(
+		bound, "Number is not greater than bound.\nActual: %v\nExpected: > %v\n", func(c int) bool { return c > 0 })
+}
+
+func (actual *assertableNumber) IsGreaterThanOrEqualTo(bound interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareTo(
+		bound, "Number is less than bound.\nActual: %v\nExpected: >= %v\n", func(c int) bool { return c >= 0 })
+}
+
+func (actual *assertableNumber) IsLessThan(bound interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareTo(
+		bound, "Number is not less than bound.\nActual: %v\nExpected: < %v\n", func(c int) bool { return c < 0 })
+}
+
+func (actual *assertableNumber) IsLessThanOrEqualTo(bound interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.compareTo(
+		bound, "Number is greater than bound.\nActual: %v\nExpected: <= %v\n", func(c int) bool { return c <= 0 })
+}
+
+func (actual *assertableNumber) IsBetween(lower, upper interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	expected := []interface{}{lower, upper}
+	actualNumber, lowerNumber, upperNumber := toNumber(actual.value), toNumber(lower), toNumber(upper)
+	if actualNumber == nil || lowerNumber == nil || upperNumber == nil {
+		return actual.unsupportedNumberResult(expected, lower, upper)
+	}
+	if boundsComparison, ok := compareNumbers(lowerNumber, upperNumber); !ok || boundsComparison > 0 {
+		actual.testContext.decoratedErrorf(
+			"Invalid range.\nLower bound: %v\nUpper bound: %v\nExpected: lower bound <= upper bound\n", lower, upper)
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+	lowerComparison, isLowerComparable := compareNumbers(actualNumber, lowerNumber)
+	upperComparison, isUpperComparable := compareNumbers(actualNumber, upperNumber)
+	passed := isLowerComparable && isUpperComparable && lowerComparison >= 0 && upperComparison <= 0
+	if !passed {
+		actual.testContext.decoratedErrorf(
+			"Number is out of range.\nActual: %v\nExpected: [%v, %v]\n", actual.value, lower, upper)
+	}
+	return actual.testContext.resultOf(passed, actual.value, expected)
+}
+
+func (actual *assertableNumber) IsCloseTo(expected, absDelta interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	delta := toNumber(absDelta)
+	if delta == nil {
+		return actual.unsupportedNumberResult(expected, absDelta)
+	} else if !actual.isValidTolerance("absolute delta", delta, absDelta) {
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+	return actual.isWithinTolerance(expected, &toleranceCheck{
+		description: "%v ± %v",
+		tolerance:   absDelta,
+		measure: func(actual, expected *number) (float64, bool) {
+			difference := absoluteDifference(actual, expected)
+			comparison, ok := compareNumbers(difference, delta)
+			return difference.float64(), ok && comparison <= 0
+		},
+	})
+}
+
+func (actual *assertableNumber) IsWithinRelative(expected, epsilon interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	relativeEpsilon := toNumber(epsilon)
+	if relativeEpsilon == nil {
+		return actual.unsupportedNumberResult(expected, epsilon)
+	} else if !actual.isValidTolerance("relative epsilon", relativeEpsilon, epsilon) {
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+	return actual.isWithinTolerance(expected, &toleranceCheck{
+		description: "%v ± %v (relative)",
+		tolerance:   epsilon,
+		measure: func(actual, expected *number) (float64, bool) {
+			difference := absoluteDifference(actual, expected)
+			if actual.rat == nil || expected.rat == nil {
+				return difference.float64(), difference.rat != nil // i.e., the same infinity
+			}
+			magnitude := new(big.Rat).Abs(actual.rat)
+			if expectedMagnitude := new(big.Rat).Abs(expected.rat); expectedMagnitude.Cmp(magnitude) > 0 {
+				magnitude = expectedMagnitude
+			}
+			if magnitude.Sign() == 0 {
+				return 0, true
+			}
+			relativeDifference := &number{rat: new(big.Rat).Quo(difference.rat, magnitude)}
+			comparison, ok := compareNumbers(relativeDifference, relativeEpsilon)
+			return relativeDifference.float64(), ok && comparison <= 0
+		},
+	})
+}
+
+func (actual *assertableNumber) IsWithinULPs(expected interface{}, ulps int) ValueAssertionResult {
+	actual.testContext.Helper()
+	if ulps < 0 {
+		actual.testContext.decoratedErrorf("Invalid ULPs.\nActual: %d\nExpected: at least 0\n", ulps)
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+	return actual.isWithinTolerance(expected, &toleranceCheck{
+		description: "%v ± %v ULPs",
+		tolerance:   ulps,
+		measure: func(actual, expected *number) (float64, bool) {
+			if actual.isNaN || expected.isNaN {
+				return math.NaN(), false
+			}
+			distance := ulpDistance(actual.float64(), expected.float64(), actual.isFloat32)
+			return float64(distance), distance <= uint64(ulps)
+		},
+	})
+}
+
+func (actual *assertableNumber) compareTo(
+	expected interface{}, format string, isExpected func(int) bool) ValueAssertionResult {
+
+	actual.testContext.Helper()
+	actualNumber, expectedNumber := toNumber(actual.value), toNumber(expected)
+	if actualNumber == nil || expectedNumber == nil {
+		return actual.unsupportedNumberResult(expected, expected)
+	}
+	comparison, isComparable := compareNumbers(actualNumber, expectedNumber)
+	passed := isComparable && isExpected(comparison)
+	if !passed {
+		actual.testContext.decoratedErrorf(format, actual.value, expected)
+	}
+	return actual.testContext.resultOf(passed, actual.value, expected)
+}
+
+func (actual *assertableNumber) isWithinTolerance(expected interface{}, check *toleranceCheck) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualValue, expectedValue := reflect.ValueOf(actual.value), reflect.ValueOf(expected)
+	if isNumberSequence(actualValue) && isNumberSequence(expectedValue) {
+		return actual.isEachWithinTolerance(actualValue, expectedValue, check)
+	}
+
+	actualNumber, expectedNumber := toNumber(actual.value), toNumber(expected)
+	if actualNumber == nil || expectedNumber == nil {
+		return actual.unsupportedNumberResult(expected, expected)
+	}
+	deviation, passed := check.measure(actualNumber, expectedNumber)
+	if !passed {
+		actual.testContext.decoratedErrorf(
+			"Number is out of tolerance.\nActual: %v\nExpected: "+check.description+"\nDeviation: %v\n",
+			actual.value, expected, check.tolerance, deviation)
+	}
+	return actual.testContext.resultOf(passed, actual.value, expected)
+}
+
+func (actual *assertableNumber) isEachWithinTolerance(
+	actualValue, expectedValue reflect.Value, check *toleranceCheck) ValueAssertionResult {
+
+	actual.testContext.Helper()
+	expected := expectedValue.Interface()
+	if actualValue.Len() != expectedValue.Len() {
+		actual.testContext.decoratedErrorf(
+			"Number count mismatch.\nActual: %d\nExpected: %d\n", actualValue.Len(), expectedValue.Len())
+		return actual.testContext.resultOf(false, actual.value, expected)
+	}
+
+	failures, worstIndex, worstDeviation := 0, -1, math.Inf(-1)
+	for i := 0; i < actualValue.Len(); i++ {
+		actualElement, expectedElement := actualValue.Index(i).Interface(), expectedValue.Index(i).Interface()
+		actualNumber, expectedNumber := toNumber(actualElement), toNumber(expectedElement)
+		if actualNumber == nil || expectedNumber == nil {
+			return actual.unsupportedElementResult(expected, i, actualElement, expectedElement)
+		}
+		deviation, passed := check.measure(actualNumber, expectedNumber)
+		if passed {
+			continue
+		}
+		failures++
+		if math.IsNaN(deviation) {
+			deviation = math.Inf(1)
+		}
+		if worstIndex < 0 || deviation > worstDeviation {
+			worstIndex, worstDeviation = i, deviation
+		}
+	}
+
+	if failures > 0 {
+		actualElement := actualValue.Index(worstIndex).Interface()
+		expectedElement := expectedValue.Index(worstIndex).Interface()
+		actual.testContext.decoratedErrorf(
+			"Numbers are out of tolerance.\nFailed elements: %d of %d\nWorst element: [%d]\n"+
+				"Actual: %v\nExpected: "+check.description+"\nDeviation: %v\n",
+			failures, actualValue.Len(), worstIndex, actualElement, expectedElement, check.tolerance, worstDeviation)
+	}
+	return actual.testContext.resultOf(failures == 0, actual.value, expected)
+}
+
+// isValidTolerance reports the specified tolerance if it's negative or NaN,
+// either of which no deviation could be within.
+func (actual *assertableNumber) isValidTolerance(name string, tolerance *number, value interface{}) bool {
+	actual.testContext.Helper()
+	comparison, ok := compareNumbers(tolerance, &number{rat: new(big.Rat)})
+	if !ok || comparison < 0 {
+		actual.testContext.decoratedErrorf("Invalid %s.\nActual: %v\nExpected: at least 0\n", name, value)
+		return false
+	}
+	return true
+}
+
+func (actual *assertableNumber) unsupportedElementResult(
+	expected interface{}, index int, actualElement, expectedElement interface{}) ValueAssertionResult {
+
+	actual.testContext.Helper()
+	element := actualElement
+	if toNumber(element) != nil {
+		element = expectedElement
+	}
+	actual.testContext.decoratedErrorf(
+		"Unsupported number type.\nElement: [%d]\nValue: %T=%v\n", index, element, element)
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+func (actual *assertableNumber) unsupportedNumberResult(
+	expected interface{}, values ...interface{}) ValueAssertionResult {
+
+	actual.testContext.Helper()
+	for _, value := range append([]interface{}{actual.value}, values...) {
+		if toNumber(value) == nil {
+			actual.testContext.decoratedErrorf("Unsupported number type.\nValue: %T=%v\n", value, value)
+			break
+		}
+	}
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+// toNumber converts the specified value to a number; it returns nil if
+// the value is not numeric.
+func toNumber(value interface{}) *number {
+	switch typed := value.(type) {
+	case *big.Int:
+		if typed != nil {
+			return &number{rat: new(big.Rat).SetInt(typed)}
+		}
+	case *big.Rat:
+		if typed != nil {
+			return &number{rat: new(big.Rat).Set(typed)}
+		}
+	case *big.Float:
+		if typed != nil && typed.IsInf() {
+			return &number{infinity: typed.Sign()}
+		} else if typed != nil {
+			rat, _ := typed.Rat(nil)
+			return &number{rat: rat}
+		}
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &number{rat: new(big.Rat).SetInt64(reflected.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &number{rat: new(big.Rat).SetInt(new(big.Int).SetUint64(reflected.Uint()))}
+	case reflect.Float32, reflect.Float64:
+		float := reflected.Float()
+		switch {
+		case math.IsNaN(float):
+			return &number{isNaN: true, isFloat32: reflected.Kind() == reflect.Float32}
+		case math.IsInf(float, 0):
+			return &number{infinity: int(math.Copysign(1, float)), isFloat32: reflected.Kind() == reflect.Float32}
+		}
+		return &number{rat: new(big.Rat).SetFloat64(float), isFloat32: reflected.Kind() == reflect.Float32}
+	}
+	return nil
+}
+
+// compareNumbers returns -1, 0, or +1 depending on whether a < b, a == b,
+// or a > b; the comparison is not possible if either is NaN.
+func compareNumbers(a, b *number) (comparison int, ok bool) {
+	if a.isNaN || b.isNaN {
+		return 0, false
+	}
+	if a.rat == nil || b.rat == nil {
+		switch {
+		case a.infinity < b.infinity:
+			return -1, true
+		case a.infinity > b.infinity:
+			return 1, true
+		}
+		return 0, true
+	}
+	return a.rat.Cmp(b.rat), true
+}
+
+func absoluteDifference(a, b *number) *number {
+	switch {
+	case a.isNaN || b.isNaN:
+		return &number{isNaN: true}
+	case a.rat == nil && b.rat == nil && a.infinity == b.infinity:
+		return &number{rat: new(big.Rat)}
+	case a.rat == nil || b.rat == nil:
+		return &number{infinity: 1}
+	}
+	return &number{rat: new(big.Rat).Abs(new(big.Rat).Sub(a.rat, b.rat))}
+}
+
+func (n *number) float64() float64 {
+	switch {
+	case n.isNaN:
+		return math.NaN()
+	case n.rat == nil:
+		return math.Inf(n.infinity)
+	}
+	float, _ := n.rat.Float64()
+	return float
+}
+
+// ulpDistance returns the number of representable floating-point values
+// between a and b.
+func ulpDistance(a, b float64, isFloat32 bool) uint64 {
+	if a == b {
+		return 0
+	}
+	var orderedA, orderedB uint64
+	if isFloat32 {
+		orderedA, orderedB = uint64(orderedFloat32Bits(float32(a))), uint64(orderedFloat32Bits(float32(b)))
+	} else {
+		orderedA, orderedB = orderedFloat64Bits(a), orderedFloat64Bits(b)
+	}
+	if orderedA > orderedB {
+		return orderedA - orderedB
+	}
+	return orderedB - orderedA
+}
+
+// orderedFloat64Bits maps the specified float to an unsigned integer such that
+// the order of floats is preserved.
+func orderedFloat64Bits(float float64) uint64 {
+	bits := math.Float64bits(float)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | 1<<63
+}
+
+func orderedFloat32Bits(float float32) uint32 {
+	bits := math.Float32bits(float)
+	if bits&(1<<31) != 0 {
+		return ^bits
+	}
+	return bits | 1<<31
+}
+
+func isNumberSequence(value reflect.Value) bool {
+	if kind := value.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return false
+	}
+	switch value.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Ptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+func ExampleAssertableNumber_Equals_pass() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"different integer widths", int64(3), 3},
+		{"signed and unsigned", uint8(3), int32(3)},
+		{"integer and float", 3, 3.0},
+		{"big integer", big.NewInt(3), uint64(3)},
+		{"big rational", big.NewRat(1, 2), 0.5},
+		{"big float", big.NewFloat(0.5), float32(0.5)},
+		{"infinities", math.Inf(1), big.NewFloat(math.Inf(1))},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualNumber(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: different integer widths
+	// Passed: signed and unsigned
+	// Passed: integer and float
+	// Passed: big integer
+	// Passed: big rational
+	// Passed: big float
+	// Passed: infinities
+}
+
+func ExampleAssertableNumber_Equals_fail() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"different values", int64(3), 4},
+		{"beyond float64 precision", uint64(math.MaxUint64), float64(math.MaxUint64)},
+		{"NaN", math.NaN(), math.NaN()},
+		{"not a number", "3", 3},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualNumber(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [different values] Number mismatch.
+	// Actual: 3
+	// Expected: 4
+	// Assertion failed successfully!
+	// file:3: [beyond float64 precision] Number mismatch.
+	// Actual: 18446744073709551615
+	// Expected: 1.8446744073709552e+19
+	// Assertion failed successfully!
+	// file:3: [NaN] Number mismatch.
+	// Actual: NaN
+	// Expected: NaN
+	// Assertion failed successfully!
+	// file:3: [not a number] Unsupported number type.
+	// Value: string=3
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsGreaterThan_pass() {
+	if For(t).ThatActualNumber(int8(1)).IsGreaterThan(0.5).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableNumber_IsGreaterThan_fail() {
+	if !mockTestContextToAssert().ThatActualNumber(0).IsGreaterThan(0).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Number is not greater than bound.
+	// Actual: 0
+	// Expected: > 0
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsGreaterThanOrEqualTo_pass() {
+	if For(t).ThatActualNumber(uint(0)).IsGreaterThanOrEqualTo(0).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableNumber_IsGreaterThanOrEqualTo_fail() {
+	if !mockTestContextToAssert().ThatActualNumber(-1).IsGreaterThanOrEqualTo(uint(0)).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Number is less than bound.
+	// Actual: -1
+	// Expected: >= 0
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsLessThan_pass() {
+	if For(t).ThatActualNumber(math.Inf(-1)).IsLessThan(int64(math.MinInt64)).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableNumber_IsLessThan_fail() {
+	if !mockTestContextToAssert().ThatActualNumber(big.NewInt(10)).IsLessThan(9.99).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Number is not less than bound.
+	// Actual: 10
+	// Expected: < 9.99
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsLessThanOrEqualTo_pass() {
+	if For(t).ThatActualNumber(float32(0.5)).IsLessThanOrEqualTo(big.NewRat(1, 2)).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableNumber_IsLessThanOrEqualTo_fail() {
+	tenth := 0.1
+	if !mockTestContextToAssert().ThatActualNumber(tenth + 0.2).IsLessThanOrEqualTo(0.3).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Number is greater than bound.
+	// Actual: 0.30000000000000004
+	// Expected: <= 0.3
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsBetween_pass() {
+	if For(t).ThatActualNumber(uint16(42)).IsBetween(42, 42.5).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableNumber_IsBetween_fail() {
+	if !mockTestContextToAssert().ThatActualNumber(13).IsBetween(0, 10).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	if !mockTestContextToAssert().ThatActualNumber(5).IsBetween(10, 0).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Number is out of range.
+	// Actual: 13
+	// Expected: [0, 10]
+	// Assertion failed successfully!
+	// file:3: Invalid range.
+	// Lower bound: 10
+	// Upper bound: 0
+	// Expected: lower bound <= upper bound
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsCloseTo_pass() {
+	tenth := 0.1
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"scalars", tenth + 0.2, 0.3},
+		{"slices", []float64{tenth + 0.2, 1}, []float64{0.3, 1}},
+		{"array and slice", [...]float32{1.0000001}, []int{1}},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualNumber(c.actual).IsCloseTo(c.expected, 1e-6).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: scalars
+	// Passed: slices
+	// Passed: array and slice
+}
+
+func ExampleAssertableNumber_IsCloseTo_fail() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+		absDelta interface{}
+	}{
+		{"scalars", 0.31, 0.3, 0.001},
+		{"slices", []float64{0.31, 1, 2.5}, []float64{0.3, 1, 2}, 0.001},
+		{"different lengths", []float64{0.3}, []float64{0.3, 1}, 0.001},
+		{"negative delta", 0.3, 0.3, -0.001},
+		{"unsupported element", []interface{}{0.3, "1"}, []float64{0.3, 1}, 0.001},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualNumber(c.actual).IsCloseTo(c.expected, c.absDelta).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [scalars] Number is out of tolerance.
+	// Actual: 0.31
+	// Expected: 0.3 ± 0.001
+	// Deviation: 0.010000000000000009
+	// Assertion failed successfully!
+	// file:3: [slices] Numbers are out of tolerance.
+	// Failed elements: 2 of 3
+	// Worst element: [2]
+	// Actual: 2.5
+	// Expected: 2 ± 0.001
+	// Deviation: 0.5
+	// Assertion failed successfully!
+	// file:3: [different lengths] Number count mismatch.
+	// Actual: 1
+	// Expected: 2
+	// Assertion failed successfully!
+	// file:3: [negative delta] Invalid absolute delta.
+	// Actual: -0.001
+	// Expected: at least 0
+	// Assertion failed successfully!
+	// file:3: [unsupported element] Unsupported number type.
+	// Element: [1]
+	// Value: string=1
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsWithinRelative_pass() {
+	if For(t).ThatActualNumber(1000001).IsWithinRelative(1e6, 1e-6).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableNumber_IsWithinRelative_fail() {
+	if !mockTestContextToAssert().ThatActualNumber([]float64{100, 0}).IsWithinRelative([]int{110, 0}, 0.05).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	if !mockTestContextToAssert().ThatActualNumber(100).IsWithinRelative(110, math.NaN()).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Numbers are out of tolerance.
+	// Failed elements: 1 of 2
+	// Worst element: [0]
+	// Actual: 100
+	// Expected: 110 ± 0.05 (relative)
+	// Deviation: 0.09090909090909091
+	// Assertion failed successfully!
+	// file:3: Invalid relative epsilon.
+	// Actual: NaN
+	// Expected: at least 0
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableNumber_IsWithinULPs_pass() {
+	tenth := 0.1
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"float64", tenth + 0.2, 0.3},
+		{"float32", math.Nextafter32(1, 2), 1},
+		{"across zero", math.Copysign(0, -1), 0.0},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualNumber(c.actual).IsWithinULPs(c.expected, 1).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: float64
+	// Passed: float32
+	// Passed: across zero
+}
+
+func ExampleAssertableNumber_IsWithinULPs_fail() {
+	if !mockTestContextToAssert().ThatActualNumber(1.0).IsWithinULPs(math.Nextafter(1, 0), 0).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	if !mockTestContextToAssert().ThatActualNumber(1.0).IsWithinULPs(2.0, -1).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: Number is out of tolerance.
+	// Actual: 1
+	// Expected: 0.9999999999999999 ± 0 ULPs
+	// Deviation: 1
+	// Assertion failed successfully!
+	// file:3: Invalid ULPs.
+	// Actual: -1
+	// Expected: at least 0
+	// Assertion failed successfully!
+}
@@ -0,0 +1,205 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+// baselineSamples is the number of benchmarks that are run to sample the
+// performance of a call, and to record its baseline.
+const baselineSamples = 5
+
+var (
+	// baselinesDirectory is where baselines are stored, relative to the
+	// directory of the package under test.
+	baselinesDirectory = "_baselines"
+
+	// runBenchmark runs benchmarks; it's replaced in tests to fake results.
+	runBenchmark = testing.Benchmark
+
+	// studentTQuantiles has the 0.975 quantiles of Student's t-distribution
+	// by degrees of freedom; i.e., the critical values of two-sided 95%
+	// confidence intervals. Larger degrees of freedom use the last one.
+	studentTQuantiles = []float64{0, 12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+		2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+		2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042, 1.960}
+)
+
+// performanceBaseline is the recorded performance of a call; it's stored as
+// JSON in "_baselines/<baseline name>.json".
+type performanceBaseline struct {
+	NanosecondsPerOperation []float64 `json:"nsPerOp"`
+	AllocationsPerOperation int64     `json:"allocsPerOp"`
+	BytesPerOperation       int64     `json:"bytesPerOp"`
+}
+
+func (callable *assertableCall) RunsWithin(baselineName string, tolerance float64) ValueAssertionResult {
+	callable.testContext.Helper()
+	path := filepath.Join(baselinesDirectory, baselineName+".json")
+	actual := callable.samplePerformance()
+	if *updatesBaselines {
+		if err := actual.write(path); err != nil {
+			callable.testContext.decoratedErrorf("Performance baseline not recorded.\nBaseline: %s\nError: %v\n",
+				path, err)
+			return callable.testContext.resultOf(false, actual, nil)
+		}
+		callable.testContext.log("", noCallerInfoLineNumber,
+			fmt.Sprintf("Performance baseline recorded: %s\n%s", path, actual))
+		return callable.testContext.resultOf(true, actual, actual)
+	}
+
+	baseline, err := readPerformanceBaseline(path)
+	if err != nil {
+		callable.testContext.decoratedErrorf("Invalid performance baseline.\nBaseline: %s\nError: %v\n"+
+			"Run the test with the -tester.update-baselines flag to record it.\n", path, err)
+		return callable.testContext.resultOf(false, actual, nil)
+	}
+
+	// The call regressed if it's slower than tolerated with 95% confidence.
+	baselineMean := mean(baseline.NanosecondsPerOperation)
+	low, high := slowdownConfidenceInterval(actual.NanosecondsPerOperation, baseline.NanosecondsPerOperation)
+	hasRegressed := low > tolerance*baselineMean
+	if hasRegressed {
+		callable.testContext.decoratedErrorf("Performance regression.\nActual: %s\nBaseline: %s\nTolerance: %+.1f%%\n"+
+			"95%% confidence interval of the slowdown: [%.1f, %.1f] ns/op ([%+.1f%%, %+.1f%%])\n%s",
+			actual, baseline, 100*tolerance, low, high, 100*low/baselineMean, 100*high/baselineMean,
+			callable.writeCPUProfile())
+	}
+	return callable.testContext.resultOf(!hasRegressed, actual, baseline)
+}
+
+func (callable *assertableCall) samplePerformance() *performanceBaseline {
+	sampled := &performanceBaseline{}
+	for i := 0; i < baselineSamples; i++ {
+		result := callable.benchmark()
+		sampled.NanosecondsPerOperation = append(sampled.NanosecondsPerOperation,
+			float64(result.T.Nanoseconds())/float64(result.N))
+		sampled.AllocationsPerOperation = result.AllocsPerOp()
+		sampled.BytesPerOperation = result.AllocedBytesPerOp()
+	}
+	return sampled
+}
+
+func (callable *assertableCall) benchmark() testing.BenchmarkResult {
+	return runBenchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			callable.call()
+		}
+	})
+}
+
+// writeCPUProfile profiles a benchmark of the call and writes the CPU
+// profile to the directory that the -tester.cpu-profiles flag specifies, if
+// any; it returns a line that reports where the profile was written, if it
+// was, or why it wasn't.
+func (callable *assertableCall) writeCPUProfile() string {
+	return callable.testContext.writeProfile(*cpuProfilesDirectory, "CPU", func(file *os.File) error {
+		if err := pprof.StartCPUProfile(file); err != nil {
+			return err
+		}
+		callable.benchmark()
+		pprof.StopCPUProfile()
+		return nil
+	})
+}
+
+// writeProfile writes a profile of the specified kind (e.g., "CPU") via the
+// specified function to a new file in the specified directory, named after
+// the test, unless the directory is empty; it returns a line that reports
+// where the profile was written, if it was, or why it wasn't.
+func (testContext *testContext) writeProfile(directory, kind string, write func(*os.File) error) string {
+	if directory == "" {
+		return ""
+	}
+
+	file, err := os.CreateTemp(directory, safeFileName(testContext.testName())+"-*."+strings.ToLower(kind)+".pprof")
+	if err == nil {
+		defer file.Close()
+		err = write(file)
+	}
+	if err != nil {
+		return fmt.Sprintf("%s profile not written: %v\n", kind, err)
+	}
+	return fmt.Sprintf("%s profile: %s\n", kind, file.Name())
+}
+
+func readPerformanceBaseline(path string) (*performanceBaseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	baseline := &performanceBaseline{}
+	if err := json.Unmarshal(content, baseline); err != nil {
+		return nil, err
+	} else if len(baseline.NanosecondsPerOperation) < 2 {
+		return nil, fmt.Errorf("%d ns/op samples; expected at least 2", len(baseline.NanosecondsPerOperation))
+	}
+	return baseline, nil
+}
+
+func (baseline *performanceBaseline) write(path string) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, append(content, '\n'), 0644)
+	}
+	return err
+}
+
+// String formats the mean of the sampled ns/op, its standard deviation, and
+// the allocations; e.g., 1520.3 ns/op ±2.1%, 3 allocs/op, 96 B/op (5 samples).
+func (baseline *performanceBaseline) String() string {
+	samples, average := baseline.NanosecondsPerOperation, mean(baseline.NanosecondsPerOperation)
+	return fmt.Sprintf("%.1f ns/op ±%.1f%%, %d allocs/op, %d B/op (%d %s)", average,
+		100*math.Sqrt(variance(samples))/average, baseline.AllocationsPerOperation, baseline.BytesPerOperation,
+		len(samples), pluralize(len(samples), "sample"))
+}
+
+// slowdownConfidenceInterval returns the 95% confidence interval of the
+// difference between the means of the specified actual and baseline
+// samples per Welch's t-test.
+func slowdownConfidenceInterval(actual, baseline []float64) (low, high float64) {
+	actualVariance, baselineVariance := variance(actual)/float64(len(actual)), variance(baseline)/float64(len(baseline))
+	standardError := math.Sqrt(actualVariance + baselineVariance)
+	degreesOfFreedom := 0.0 // Welch–Satterthwaite equation
+	if standardError > 0 {
+		degreesOfFreedom = math.Pow(standardError, 4) / (actualVariance*actualVariance/float64(len(actual)-1) +
+			baselineVariance*baselineVariance/float64(len(baseline)-1))
+	}
+
+	quantile := studentTQuantiles[len(studentTQuantiles)-1]
+	if df := int(degreesOfFreedom); df < len(studentTQuantiles)-1 {
+		quantile = studentTQuantiles[int(math.Max(1, float64(df)))] // rounds down to be conservative
+	}
+	difference := mean(actual) - mean(baseline)
+	return difference - quantile*standardError, difference + quantile*standardError
+}
+
+func mean(samples []float64) float64 {
+	sum := 0.0
+	for _, sample := range samples {
+		sum += sample
+	}
+	return sum / float64(len(samples))
+}
+
+// variance returns the unbiased sample variance of the specified samples.
+func variance(samples []float64) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	average, sum := mean(samples), 0.0
+	for _, sample := range samples {
+		sum += (sample - average) * (sample - average)
+	}
+	return sum / float64(len(samples)-1)
+}
@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBenchmarks makes benchmarks report the specified ns/op in turn, with
+// 2 allocations that total 64 B per op, and returns a function that undoes
+// that.
+func fakeBenchmarks(nanosecondsPerOperation ...float64) (restore func()) {
+	original, i := runBenchmark, 0
+	runBenchmark = func(func(*testing.B)) testing.BenchmarkResult {
+		defer func() { i++ }()
+		elapsed := time.Duration(1000 * nanosecondsPerOperation[i%len(nanosecondsPerOperation)])
+		return testing.BenchmarkResult{N: 1000, T: elapsed, MemAllocs: 2000, MemBytes: 64000}
+	}
+	return func() { runBenchmark = original }
+}
+
+func useBaselinesDirectory(directory string) (restore func()) {
+	original := baselinesDirectory
+	baselinesDirectory = directory
+	return func() { baselinesDirectory = original }
+}
+
+func ExampleAssertableCall_RunsWithin_pass() {
+	defer useBaselinesDirectory("testdata/baselines")()
+	defer fakeBenchmarks(1010, 990, 1020, 1000, 980)()
+	if For(t).ThatCalling(func() {}).RunsWithin("sum", 0.1).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableCall_RunsWithin_fail() {
+	defer useBaselinesDirectory("testdata/baselines")()
+	defer fakeBenchmarks(1310, 1290, 1320, 1300, 1280)()
+	mockTestContextToAssert().ThatCalling(func() {}).RunsWithin("sum", 0.1)
+	// Output:
+	// file:3: Performance regression.
+	// Actual: 1300.0 ns/op ±1.2%, 2 allocs/op, 64 B/op (5 samples)
+	// Baseline: 1000.0 ns/op ±1.6%, 2 allocs/op, 64 B/op (5 samples)
+	// Tolerance: +10.0%
+	// 95% confidence interval of the slowdown: [276.9, 323.1] ns/op ([+27.7%, +32.3%])
+}
+
+func TestRunsWithinPassesWithinTheNoiseOfTheBaseline(t *testing.T) {
+	defer useBaselinesDirectory("testdata/baselines")()
+	defer fakeBenchmarks(1150, 1000, 1200, 950, 1100)() // slower on average but not significantly so
+	recorder := &logRecorder{TB: t}
+	For(t).ThatActual(For(recorder).ThatCalling(func() {}).RunsWithin("sum", 0.05).Passed()).IsTrue()
+	For(t).ThatActualCollection(recorder.logs).IsEmpty()
+}
+
+func TestRunsWithinFailsWithoutABaseline(t *testing.T) {
+	defer useBaselinesDirectory(t.TempDir())()
+	defer fakeBenchmarks(1000)()
+	recorder := &logRecorder{TB: t}
+	For(t).ThatActual(For(recorder).ThatCalling(func() {}).RunsWithin("missing", 0.1).Passed()).IsFalse()
+	if For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		message := recorder.logs[0]
+		For(t).ThatActual(strings.HasPrefix(message, "Invalid performance baseline.\nBaseline: "+
+			filepath.Join(baselinesDirectory, "missing.json")+"\nError: ")).IsTrue()
+		For(t).ThatActual(strings.HasSuffix(message, "Run the test with the -tester.update-baselines flag to "+
+			"record it.")).IsTrue()
+	}
+}
+
+func TestRunsWithinRecordsBaselinesWhenUpdating(t *testing.T) {
+	defer func(original bool) { *updatesBaselines = original }(*updatesBaselines)
+	defer useBaselinesDirectory(filepath.Join(t.TempDir(), "_baselines"))()
+	defer fakeBenchmarks(1010, 990, 1020, 1000, 980)()
+	*updatesBaselines = true
+	recorder := &logRecorder{TB: t}
+	For(t).ThatActual(For(recorder).ThatCalling(func() {}).RunsWithin("sum", 0.1).Passed()).IsTrue()
+	For(t).ThatActual(recorder.failed).IsFalse()
+	path := filepath.Join(baselinesDirectory, "sum.json")
+	For(t).ThatActualCollection(recorder.logs).ContainsInOrder("Performance baseline recorded: " + path +
+		"\n1000.0 ns/op ±1.6%, 2 allocs/op, 64 B/op (5 samples)")
+
+	recorded, err := os.ReadFile(path)
+	For(t).ThatActualError(err).IsNil()
+	expected, err := os.ReadFile("testdata/baselines/sum.json")
+	For(t).ThatActualError(err).IsNil()
+	For(t).ThatActualString(string(recorded)).Equals(string(expected))
+}
+
+func TestRunsWithinWritesCPUProfilesOnRegressions(t *testing.T) {
+	defer func(original string) { *cpuProfilesDirectory = original }(*cpuProfilesDirectory)
+	defer useBaselinesDirectory("testdata/baselines")()
+	defer fakeBenchmarks(2000)()
+	*cpuProfilesDirectory = t.TempDir()
+	recorder := &logRecorder{TB: t}
+	For(t).ThatActual(For(recorder).ThatCalling(func() {}).RunsWithin("sum", 0.1).Passed()).IsFalse()
+	if !For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		return
+	}
+
+	_, profile, hasProfile := strings.Cut(recorder.logs[0], "\nCPU profile: ")
+	if For(t).ThatActual(hasProfile).IsTrue().Passed() {
+		_, err := os.Stat(profile)
+		For(t).ThatActualError(err).IsNil()
+		expectedPrefix := filepath.Join(*cpuProfilesDirectory, "TestRunsWithinWritesCPUProfilesOnRegressions-")
+		For(t).ThatActual(strings.HasPrefix(profile, expectedPrefix)).IsTrue()
+	}
+}
+
+func TestSlowdownConfidenceInterval(t *testing.T) {
+	cases := []struct {
+		id               string
+		actual, baseline []float64
+		low, high        float64
+	}{
+		{"identical", []float64{10, 10}, []float64{10, 10}, 0, 0},
+		{"constant slowdown", []float64{12, 12, 12}, []float64{10, 10, 10}, 2, 2},
+		{"noisy", []float64{1310, 1290, 1320, 1300, 1280}, []float64{1010, 990, 1020, 1000, 980}, 276.94, 323.06},
+	}
+
+	for _, c := range cases {
+		low, high := slowdownConfidenceInterval(c.actual, c.baseline)
+		For(t, c.id, "low").ThatActualNumber(low).IsCloseTo(c.low, 0.1)
+		For(t, c.id, "high").ThatActualNumber(high).IsCloseTo(c.high, 0.1)
+	}
+}
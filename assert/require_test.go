@@ -0,0 +1,138 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+// failNowRecorder records whether FailNow was called; like testing.T, FailNow
+// stops the calling goroutine.
+type failNowRecorder struct {
+	testing.TB
+	failedNow bool
+}
+
+func (recorder *failNowRecorder) FailNow() {
+	recorder.failedNow = true
+	runtime.Goexit()
+}
+
+// runRequiring runs the specified function in a goroutine of its own, like
+// testing.T does for tests, with a mock test context that requires assertions
+// to pass, and returns true if the function was stopped by a failure.
+func runRequiring(assertions func(TestContext)) bool {
+	recorder := &failNowRecorder{TB: t}
+	mock := Require(recorder).(*testContext)
+	mock.caller = func() (string, int) { return "file", 3 }
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assertions(mock)
+	}()
+	<-done
+	return recorder.failedNow
+}
+
+func ExampleRequire_pass() {
+	if !runRequiring(func(a TestContext) { a.ThatActual(42).IsNotNil() }) {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleRequire_fail() {
+	stopped := runRequiring(func(a TestContext) {
+		users := map[string]*struct{ Name string }{}
+		user, ok := users["ann"]
+		a.ThatActual(ok).IsTrue()
+		fmt.Println(user.Name) // not reached
+	})
+
+	if stopped {
+		fmt.Println("Test stopped successfully!")
+	}
+	// Output:
+	// file:3: Value mismatch.
+	// Actual: false
+	// Expected: true
+	// Test stopped successfully!
+}
+
+func ExampleRequire_softly() {
+	stopped := runRequiring(func(a TestContext) {
+		a.Softly(func(a TestContext) {
+			a.ThatActual(42).Equals(13)
+			fmt.Println("Soft assertions go on...")
+		})
+		fmt.Println("Not reached!")
+	})
+
+	if stopped {
+		fmt.Println("Test stopped successfully!")
+	}
+	// Output:
+	// Soft assertions go on...
+	// file:3: Soft assertions failed (1 failure).
+	// file:3: Value mismatch.
+	// Actual: 42
+	// Expected: 13
+	// Test stopped successfully!
+}
+
+func TestRequireInSubtestsAndCleanups(t *testing.T) {
+	t.Run("subtest", func(t *testing.T) {
+		t.Cleanup(func() {
+			Require(t).ThatActual(t.Failed()).IsFalse()
+		})
+		Require(t, "subtest").ThatActualString(t.Name()).Equals("TestRequireInSubtestsAndCleanups/subtest")
+	})
+}
+
+// skippingRecorder records what's logged via a subtest and whether FailNow
+// was called; like testing.T, FailNow stops the calling goroutine, but it
+// does so by skipping the subtest, so that the parent test doesn't fail.
+type skippingRecorder struct {
+	logRecorder
+	failedNow bool
+}
+
+func (recorder *skippingRecorder) FailNow() {
+	recorder.failedNow = true
+	recorder.TB.SkipNow()
+}
+
+func TestRequireStopsFailingSubtests(t *testing.T) {
+	var recorder *skippingRecorder
+	isReached := false
+	t.Run("subtest", func(t *testing.T) {
+		recorder = &skippingRecorder{logRecorder: logRecorder{TB: t}}
+		Require(recorder).ThatActual(42).Equals(13)
+		isReached = true
+	})
+
+	For(t).ThatActual(recorder.failedNow).IsTrue()
+	For(t).ThatActual(isReached).IsFalse()
+	For(t).ThatActual(recorder.logs).Equals([]string{"Value mismatch.\nActual: 42\nExpected: 13"})
+	For(t).ThatActual(t.Failed()).IsFalse()
+}
+
+func TestRequireStopsFailingCleanups(t *testing.T) {
+	var recorder *skippingRecorder
+	isReached, isNextCleanupRun := false, false
+	t.Run("subtest", func(t *testing.T) {
+		recorder = &skippingRecorder{logRecorder: logRecorder{TB: t}}
+		t.Cleanup(func() { isNextCleanupRun = true }) // cleanups run last added first
+		t.Cleanup(func() {
+			Require(recorder).ThatActual(42).Equals(13)
+			isReached = true
+		})
+	})
+
+	For(t).ThatActual(recorder.failedNow).IsTrue()
+	For(t).ThatActual(isReached).IsFalse()
+	For(t).ThatActual(isNextCleanupRun).IsTrue()
+	For(t).ThatActual(recorder.logs).Equals([]string{"Value mismatch.\nActual: 42\nExpected: 13"})
+	For(t).ThatActual(t.Failed()).IsFalse()
+}
@@ -28,12 +28,13 @@ type ValueAssertionResult interface {
 	Passed() bool
 
 	// ThenDiffOnFail performed a diff of asserted values on assertion failure;
-	// it prints a pretty diff of the actual and expected values used in
-	// the failed assertion, in that order.
+	// it logs a structural diff of the actual and expected values used in
+	// the failed assertion, in that order, via the test.
 	// Returns the current ValueAssertionResult to allow for call-chaining.
 	ThenDiffOnFail() ValueAssertionResult
 
-	// ThenPrettyPrintOnFail pretty-prints asserted values on assertion failure;
+	// ThenPrettyPrintOnFail pretty-prints asserted values via the test on
+	// assertion failure.
 	// Returns the current ValueAssertionResult to allow for call-chaining.
 	ThenPrettyPrintOnFail() ValueAssertionResult
 
@@ -46,8 +47,13 @@ type ValueAssertionResult interface {
 
 type valueAssertionResult struct {
 	bool
-	actual   interface{}
-	expected interface{}
+	actual      interface{}
+	expected    interface{}
+	testContext *testContext
+}
+
+func (testContext *testContext) resultOf(passed bool, actual, expected interface{}) *valueAssertionResult {
+	return &valueAssertionResult{bool: passed, actual: actual, expected: expected, testContext: testContext}
 }
 
 func (result *valueAssertionResult) Passed() bool {
@@ -55,11 +61,19 @@ func (result *valueAssertionResult) Passed() bool {
 }
 
 func (result *valueAssertionResult) ThenDiffOnFail() ValueAssertionResult {
-	return result.ThenRunOnFail(PrintDiff)
+	result.testContext.Helper()
+	if !result.Passed() {
+		result.testContext.log("", noCallerInfoLineNumber, formatDiff(result.actual, result.expected))
+	}
+	return result
 }
 
 func (result *valueAssertionResult) ThenPrettyPrintOnFail() ValueAssertionResult {
-	return result.ThenRunOnFail(PrettyPrint)
+	result.testContext.Helper()
+	if !result.Passed() {
+		result.testContext.log("", noCallerInfoLineNumber, formatPretty(result.actual, result.expected))
+	}
+	return result
 }
 
 func (result *valueAssertionResult) ThenRunOnFail(action func(actual, expected interface{})) ValueAssertionResult {
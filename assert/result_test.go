@@ -10,12 +10,12 @@ func ExampleValueAssertionResult_ThenDiffOnFail_assertionFailed() {
 	expected := &mail.Address{Name: "Erlich Bachman", Address: "erlich@pp.io"}
 	mockTestContextToAssert().ThatActual(address).Equals(expected).ThenDiffOnFail()
 	// Output:
-	// 	file:3: Value mismatch.
-	// Actual: &mail.Address{Name:"Richard Hendricks", Address:"richard@pp.io"}
-	// Expected: &mail.Address{Name:"Erlich Bachman", Address:"erlich@pp.io"}
+	// file:3: Value mismatch (2 differences in *mail.Address).
+	// Actual.Name: "Richard Hendricks" != "Erlich Bachman"
+	// Actual.Address: "richard@pp.io" != "erlich@pp.io"
 	// Diff:
-	// Name: "Richard Hendricks" != "Erlich Bachman"
-	// Address: "richard@pp.io" != "erlich@pp.io"
+	// Actual.Name: "Richard Hendricks" != "Erlich Bachman"
+	// Actual.Address: "richard@pp.io" != "erlich@pp.io"
 }
 
 func ExampleValueAssertionResult_ThenDiffOnFail_assertionPassed() {
@@ -30,9 +30,9 @@ func ExampleValueAssertionResult_ThenPrettyPrintOnFail_assertionFailed() {
 	expected := &mail.Address{Name: "Erlich Bachman", Address: "erlich@pp.io"}
 	mockTestContextToAssert().ThatActual(address).Equals(expected).ThenPrettyPrintOnFail()
 	// Output:
-	// file:3: Value mismatch.
-	// Actual: &mail.Address{Name:"Richard Hendricks", Address:"richard@pp.io"}
-	// Expected: &mail.Address{Name:"Erlich Bachman", Address:"erlich@pp.io"}
+	// file:3: Value mismatch (2 differences in *mail.Address).
+	// Actual.Name: "Richard Hendricks" != "Erlich Bachman"
+	// Actual.Address: "richard@pp.io" != "erlich@pp.io"
 	// Pretty:
 	// Actual: "Richard Hendricks" <richard@pp.io>
 	// Expected: "Erlich Bachman" <erlich@pp.io>
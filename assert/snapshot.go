@@ -0,0 +1,246 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const snapshotHeaderPrefix = "--- "
+
+var (
+	// snapshotDirectory is where snapshot files are stored, relative to the
+	// directory of the package under test.
+	snapshotDirectory = "_snapshots"
+
+	// snapshotsLock guards the snapshot files, which are loaded once, and
+	// the number of snapshots that each running test took so far.
+	snapshotsLock  sync.Mutex
+	snapshotFiles  = map[string]*snapshotFile{}
+	snapshotCounts = map[string]int{}
+)
+
+// snapshotFile holds the snapshots of a test and its subtests, keyed by the
+// name of the (sub)test and the number of the snapshot in it; e.g.,
+// "TestRender/dark_mode 2". It's stored as a sequence of snapshots, each
+// after a header line; for example:
+//     --- TestRender/dark_mode 1
+//     &render.page{
+//         Title: "Home",
+//     }
+type snapshotFile struct {
+	path      string
+	snapshots map[string]string
+	used      map[string]bool
+}
+
+// ObsoleteSnapshots returns the snapshots, under the "_snapshots" directory
+// of the package under test, that no assertion used since the tests started,
+// as "<snapshot file>: <test name> <number>"; e.g.,
+// "_snapshots/TestRender.snap: TestRender/dark_mode 2". It's meant to be
+// called by TestMain after the tests ran in full (i.e., without the -run
+// flag), like UnusedGoldenFiles.
+func ObsoleteSnapshots() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(snapshotDirectory, "*.snap"))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotsLock.Lock()
+	defer snapshotsLock.Unlock()
+	obsolete := []string{}
+	for _, path := range paths {
+		file, err := loadSnapshotFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range file.sortedKeys() {
+			if !file.used[key] {
+				obsolete = append(obsolete, path+": "+key)
+			}
+		}
+	}
+	return obsolete, nil
+}
+
+func (actual *assertableValue) MatchesSnapshot() ValueAssertionResult {
+	actual.testContext.Helper()
+	snapshot := formatSnapshot(actual.value)
+	key, path, expected, err := actual.testContext.takeSnapshot(snapshot)
+	switch {
+	case err != nil && updatesGoldenFiles():
+		actual.testContext.decoratedErrorf("Snapshot not updated.\nSnapshot file: %s\nError: %v\n", path, err)
+		return actual.testContext.resultOf(false, snapshot, nil)
+	case err != nil:
+		actual.testContext.decoratedErrorf("Invalid snapshot file.\nSnapshot file: %s\nError: %v\n", path, err)
+		return actual.testContext.resultOf(false, snapshot, nil)
+	case updatesGoldenFiles():
+		if snapshot != expected {
+			actual.testContext.log("", noCallerInfoLineNumber, "Snapshot updated: "+key+" in "+path+"\n")
+		}
+		return actual.testContext.resultOf(true, snapshot, snapshot)
+	case expected == "":
+		actual.testContext.decoratedErrorf("Snapshot not found.\nSnapshot: %s in %s\n"+
+			"Run the test with the -tester.update flag to record it.\n", key, path)
+		return actual.testContext.resultOf(false, snapshot, nil)
+	case snapshot != expected:
+		actual.testContext.decoratedErrorf("%s", formatSnapshotMismatch(key, path, snapshot, expected))
+	}
+	return actual.testContext.resultOf(snapshot == expected, snapshot, expected)
+}
+
+// formatSnapshotMismatch reports the differences between the specified
+// snapshots, labeled by their paths (e.g., Actual.Items[2].Price) unless the
+// recorded snapshot can't be compared value by value, followed by a line diff.
+func formatSnapshotMismatch(key, path, actual, expected string) string {
+	header, differences := "Snapshot mismatch.", ""
+	if diffs, ok := diffSnapshots(actual, expected); ok && len(diffs) > 0 {
+		header = fmt.Sprintf("Snapshot mismatch (%d %s).", len(diffs), pluralize(len(diffs), "difference"))
+		differences = formatDifferences(diffs)
+	}
+	return fmt.Sprintf("%s\nSnapshot: %s in %s\n%s%s"+
+		"Run the test with the -tester.update flag to update it.\n", header, key, path, differences,
+		formatContentDiff([]byte(actual), []byte(expected)))
+}
+
+// takeSnapshot numbers the specified snapshot of the test and returns its
+// key, the path of the snapshot file of the test, and the snapshot that
+// was recorded under the key, if any. If snapshots are updated, it records
+// the specified snapshot instead, and returns the one it replaced.
+func (testContext *testContext) takeSnapshot(snapshot string) (key, path, recorded string, err error) {
+	testName := testContext.testName()
+	path = filepath.Join(snapshotDirectory, safeFileName(strings.SplitN(testName, "/", 2)[0])+".snap")
+
+	snapshotsLock.Lock()
+	defer snapshotsLock.Unlock()
+	file, err := loadSnapshotFile(path)
+	if err != nil {
+		return "", path, "", err
+	}
+
+	snapshotCounts[testName]++
+	if snapshotCounts[testName] == 1 {
+		testContext.Cleanup(func() { finishSnapshots(testName, file) })
+	}
+	key = testName + " " + strconv.Itoa(snapshotCounts[testName])
+	file.used[key] = true
+	recorded = file.snapshots[key]
+	if updatesGoldenFiles() && snapshot != recorded {
+		file.snapshots[key] = snapshot
+		err = file.write()
+	}
+	return key, path, recorded, err
+}
+
+// finishSnapshots resets the count of the snapshots of the specified test
+// for its next run (e.g., with the -count flag); if snapshots are updated,
+// it also deletes the obsolete snapshots of the test, which are numbered
+// after the ones it took.
+func finishSnapshots(testName string, file *snapshotFile) {
+	snapshotsLock.Lock()
+	defer snapshotsLock.Unlock()
+	count := snapshotCounts[testName]
+	delete(snapshotCounts, testName)
+	if !updatesGoldenFiles() {
+		return
+	}
+
+	hasDeleted := false
+	for key := range file.snapshots {
+		name, number := parseSnapshotKey(key)
+		if name == testName && number > count {
+			delete(file.snapshots, key)
+			hasDeleted = true
+		}
+	}
+	if hasDeleted {
+		file.write() // best effort as the test is over
+	}
+}
+
+// loadSnapshotFile returns the snapshot file at the specified path, which is
+// read once, or an empty one if it doesn't exist. It must be called while
+// holding snapshotsLock.
+func loadSnapshotFile(path string) (*snapshotFile, error) {
+	if file, ok := snapshotFiles[path]; ok {
+		return file, nil
+	}
+
+	file := &snapshotFile{path: path, snapshots: map[string]string{}, used: map[string]bool{}}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		snapshotFiles[path] = file
+		return file, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	key, lines := "", []string{}
+	addSnapshot := func() {
+		for len(lines) > 0 && lines[len(lines)-1] == "" { // drops the blank lines that separate snapshots
+			lines = lines[:len(lines)-1]
+		}
+		if key != "" {
+			file.snapshots[key] = strings.Join(lines, "\n")
+		}
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, snapshotHeaderPrefix):
+			addSnapshot()
+			key, lines = strings.TrimPrefix(line, snapshotHeaderPrefix), []string{}
+		case key == "" && line != "":
+			return nil, fmt.Errorf("line %d: expected a %q header", i+1, snapshotHeaderPrefix+"<test name> <number>")
+		default:
+			lines = append(lines, line)
+		}
+	}
+	addSnapshot()
+	snapshotFiles[path] = file
+	return file, nil
+}
+
+func (file *snapshotFile) write() error {
+	var builder strings.Builder
+	for i, key := range file.sortedKeys() {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "%s%s\n%s\n", snapshotHeaderPrefix, key, file.snapshots[key])
+	}
+	if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file.path, []byte(builder.String()), 0644)
+}
+
+// sortedKeys returns the keys of the snapshots ordered by test name, then by
+// number.
+func (file *snapshotFile) sortedKeys() []string {
+	keys := []string{}
+	for key := range file.snapshots {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, aNumber := parseSnapshotKey(keys[i])
+		b, bNumber := parseSnapshotKey(keys[j])
+		if a != b {
+			return a < b
+		}
+		return aNumber < bNumber
+	})
+	return keys
+}
+
+func parseSnapshotKey(key string) (testName string, number int) {
+	i := strings.LastIndex(key, " ")
+	if i < 0 {
+		return key, 0
+	}
+	number, _ = strconv.Atoi(key[i+1:])
+	return key[:i], number
+}
@@ -0,0 +1,109 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// useSnapshotFile makes snapshots be stored in a temporary directory, in
+// which it writes the specified content to the snapshot file of the test
+// unless it's empty, and returns the path of said file.
+func useSnapshotFile(t *testing.T, content string) string {
+	original := snapshotDirectory
+	snapshotDirectory = t.TempDir()
+	t.Cleanup(func() { snapshotDirectory = original })
+	path := filepath.Join(snapshotDirectory, t.Name()+".snap")
+	if content != "" {
+		For(t).ThatActualError(os.WriteFile(path, []byte(content), 0644)).IsNil()
+	}
+	return path
+}
+
+func TestSnapshotsAreMatched(t *testing.T) {
+	useSnapshotFile(t, "--- TestSnapshotsAreMatched 1\n"+
+		"map[string]int{\n    \"a\": 1,\n    \"b\": 2,\n}\n\n"+
+		"--- TestSnapshotsAreMatched 2\n\"done\"\n")
+	recorder := &logRecorder{TB: t}
+	For(t).ThatActual(For(recorder).ThatActual(map[string]int{"b": 2, "a": 1}).MatchesSnapshot().Passed()).IsTrue()
+	For(t).ThatActual(For(recorder).ThatActual("done").MatchesSnapshot().Passed()).IsTrue()
+	For(t).ThatActualCollection(recorder.logs).IsEmpty()
+}
+
+func TestSnapshotsOfUnexportedTimesAreMatched(t *testing.T) {
+	useSnapshotFile(t, "--- TestSnapshotsOfUnexportedTimesAreMatched 1\n"+
+		"assert.snapshotEvent{\n    at: time.Time(\"2019-05-01T00:00:00Z\"),\n}\n")
+	event := snapshotEvent{at: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)}
+	For(t).ThatActual(event).MatchesSnapshot()
+}
+
+func TestSnapshotMismatchesAreReported(t *testing.T) {
+	path := useSnapshotFile(t, "--- TestSnapshotMismatchesAreReported 1\n"+
+		"&assert.snapshotNode{\n    Name: \"a\",\n    Next: (*assert.snapshotNode)(nil),\n}\n")
+	recorder := &logRecorder{TB: t}
+	For(recorder).ThatActual(&snapshotNode{Name: "b"}).MatchesSnapshot()
+	For(recorder).ThatActual(42).MatchesSnapshot()
+
+	For(t).ThatActual(recorder.logs).Equals([]string{
+		"Snapshot mismatch (1 difference).\nSnapshot: TestSnapshotMismatchesAreReported 1 in " + path + "\n" +
+			"Actual.Name: \"b\" != \"a\"\n--- Actual\n+++ Expected\n@@ -1,4 +1,4 @@\n &assert.snapshotNode{\n" +
+			"-    Name: \"b\",\n+    Name: \"a\",\n     Next: (*assert.snapshotNode)(nil),\n }\n" +
+			"\\ No newline at end of string\n" +
+			"First difference: line 2, column 12; actual 'b' (U+0062), expected 'a' (U+0061)\n" +
+			"Run the test with the -tester.update flag to update it.",
+		"Snapshot not found.\nSnapshot: TestSnapshotMismatchesAreReported 2 in " + path + "\n" +
+			"Run the test with the -tester.update flag to record it.",
+	})
+}
+
+func TestSnapshotsAreUpdated(t *testing.T) {
+	path := useSnapshotFile(t, "--- TestSnapshotsAreUpdated/dark_mode 1\n\"stale\"\n\n"+
+		"--- TestSnapshotsAreUpdated/dark_mode 2\n\"obsolete\"\n\n"+
+		"--- TestSnapshotsAreUpdated/light_mode 1\n\"untouched\"\n")
+	t.Setenv(updateGoldenFilesEnvironmentVariable, "1")
+	recorder := &logRecorder{TB: t}
+	t.Run("dark mode", func(t *testing.T) {
+		recorder.TB = t
+		For(recorder).ThatActual([]string{"fresh"}).MatchesSnapshot()
+	})
+
+	For(t).ThatActual(recorder.logs).Equals([]string{
+		"Snapshot updated: TestSnapshotsAreUpdated/dark_mode 1 in " + path})
+	content, err := os.ReadFile(path)
+	For(t).ThatActualError(err).IsNil()
+	For(t).ThatActualString(string(content)).Equals("--- TestSnapshotsAreUpdated/dark_mode 1\n" +
+		"[]string{\n    \"fresh\",\n}\n\n" +
+		"--- TestSnapshotsAreUpdated/light_mode 1\n\"untouched\"\n")
+}
+
+func TestObsoleteSnapshots(t *testing.T) {
+	path := useSnapshotFile(t, "--- TestObsoleteSnapshots 1\n42\n\n--- TestObsoleteSnapshots 2\n13\n")
+	For(t).ThatActual(42).MatchesSnapshot()
+	obsolete, err := ObsoleteSnapshots()
+	For(t).ThatActualError(err).IsNil()
+	For(t).ThatActual(obsolete).Equals([]string{path + ": TestObsoleteSnapshots 2"})
+}
+
+func TestInvalidSnapshotFilesAreReported(t *testing.T) {
+	path := useSnapshotFile(t, "42\n")
+	recorder := &logRecorder{TB: t}
+	For(recorder).ThatActual(42).MatchesSnapshot()
+	if For(t).ThatActualCollection(recorder.logs).HasLength(1).Passed() {
+		For(t).ThatActualString(recorder.logs[0]).Equals("Invalid snapshot file.\nSnapshot file: " + path +
+			"\nError: line 1: expected a \"--- <test name> <number>\" header")
+	}
+}
+
+func TestSnapshotCountsAreResetAfterTests(t *testing.T) {
+	useSnapshotFile(t, "--- TestSnapshotCountsAreResetAfterTests/run 1\n42\n")
+	testName := ""
+	t.Run("run", func(t *testing.T) {
+		testName = t.Name()
+		For(t).ThatActual(42).MatchesSnapshot()
+	})
+	snapshotsLock.Lock()
+	_, isCounted := snapshotCounts[testName]
+	snapshotsLock.Unlock()
+	For(t).ThatActual(isCounted).IsFalse()
+}
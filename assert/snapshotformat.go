@@ -0,0 +1,371 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const snapshotIndent = "    "
+
+// snapshotFormatter formats values deterministically, in a Go-like syntax
+// similar to that of kr/pretty: map entries are sorted by key, pointers are
+// followed rather than printed as addresses, and cycles are marked.
+//
+// kr/pretty itself isn't used since its output isn't stable across runs:
+// it prints map entries in iteration order, which a pass over its output
+// can't sort as entries may span lines, and channels and cycles (except
+// through addressable structs) as addresses; it also cuts values off after
+// ten levels of nesting and aligns entries with tabs, so that changing one
+// value may realign its neighbors and bloat snapshot diffs.
+type snapshotFormatter struct {
+	builder  strings.Builder
+	visiting map[uintptr]bool // the pointers and maps being formatted, to detect cycles
+}
+
+// formatSnapshot formats the specified value for a snapshot; for example:
+//     &assert.order{
+//         ID: 42,
+//         Items: []string{
+//             "apple",
+//         },
+//         Prices: map[string]float64{
+//             "apple": 1.5,
+//         },
+//     }
+func formatSnapshot(value interface{}) string {
+	formatter := &snapshotFormatter{visiting: map[uintptr]bool{}}
+	formatter.format(reflect.ValueOf(value), "", true)
+	return formatter.builder.String()
+}
+
+// format formats the specified value, whose nested lines are indented by
+// the specified indent, prefixed by its type if specified and unless it's
+// the default type of its literal; e.g., int or string.
+func (formatter *snapshotFormatter) format(value reflect.Value, indent string, showsType bool) {
+	if !value.IsValid() {
+		formatter.builder.WriteString("nil")
+		return
+	}
+	value = accessible(value) // e.g., to read a time.Time reached via unexported fields, like diffValues does
+
+	typeName := value.Type().String()
+	switch value.Kind() {
+	case reflect.Bool:
+		formatter.formatLiteral(strconv.FormatBool(value.Bool()), typeName, showsType && typeName != "bool")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		formatter.formatLiteral(strconv.FormatInt(value.Int(), 10), typeName, showsType && typeName != "int")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		formatter.formatLiteral(strconv.FormatUint(value.Uint(), 10), typeName, showsType)
+	case reflect.Float32, reflect.Float64:
+		literal := strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits())
+		if !strings.ContainsAny(literal, ".eIN") { // e.g., 1.0 rather than 1, which would read as an int
+			literal += ".0"
+		}
+		formatter.formatLiteral(literal, typeName, showsType && typeName != "float64")
+	case reflect.Complex64, reflect.Complex128:
+		formatter.formatLiteral(fmt.Sprint(value.Complex()), typeName, showsType)
+	case reflect.String:
+		formatter.formatLiteral(strconv.Quote(value.String()), typeName, showsType && typeName != "string")
+	case reflect.Interface:
+		formatter.format(value.Elem(), indent, true)
+	case reflect.Ptr:
+		formatter.formatPointer(value, indent)
+	case reflect.Struct:
+		formatter.formatStruct(value, indent, showsType)
+	case reflect.Slice, reflect.Array:
+		formatter.formatSequence(value, indent, showsType)
+	case reflect.Map:
+		formatter.formatMap(value, indent, showsType)
+	default: // channels, functions, and unsafe pointers, whose addresses vary
+		formatter.formatReference(value)
+	}
+}
+
+func (formatter *snapshotFormatter) formatLiteral(literal, typeName string, showsType bool) {
+	if showsType {
+		fmt.Fprintf(&formatter.builder, "%s(%s)", typeName, literal)
+	} else {
+		formatter.builder.WriteString(literal)
+	}
+}
+
+func (formatter *snapshotFormatter) formatReference(value reflect.Value) {
+	if value.IsNil() {
+		fmt.Fprintf(&formatter.builder, "(%s)(nil)", value.Type())
+	} else {
+		fmt.Fprintf(&formatter.builder, "(%s)(...)", value.Type())
+	}
+}
+
+func (formatter *snapshotFormatter) formatPointer(value reflect.Value, indent string) {
+	switch {
+	case value.IsNil():
+		fmt.Fprintf(&formatter.builder, "(%s)(nil)", value.Type())
+	case formatter.visiting[value.Pointer()]:
+		fmt.Fprintf(&formatter.builder, "(%s)(<cycle>)", value.Type())
+	default:
+		formatter.visiting[value.Pointer()] = true
+		defer delete(formatter.visiting, value.Pointer())
+		formatter.builder.WriteString("&")
+		formatter.format(value.Elem(), indent, true)
+	}
+}
+
+func (formatter *snapshotFormatter) formatStruct(value reflect.Value, indent string, showsType bool) {
+	if value.Type() == timeType {
+		instant := value.Interface().(time.Time)
+		formatter.formatLiteral(strconv.Quote(instant.Format(time.RFC3339Nano)), "time.Time", true)
+		return
+	}
+
+	formatter.formatTypeIf(showsType, value.Type())
+	if value.NumField() == 0 {
+		formatter.builder.WriteString("{}")
+		return
+	}
+	formatter.builder.WriteString("{\n")
+	for i := 0; i < value.NumField(); i++ {
+		formatter.builder.WriteString(indent + snapshotIndent + value.Type().Field(i).Name + ": ")
+		formatter.format(value.Field(i), indent+snapshotIndent, true)
+		formatter.builder.WriteString(",\n")
+	}
+	formatter.builder.WriteString(indent + "}")
+}
+
+func (formatter *snapshotFormatter) formatSequence(value reflect.Value, indent string, showsType bool) {
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		formatter.formatNil(value, showsType)
+		return
+	} else if value.Type().Elem().Kind() == reflect.Uint8 { // e.g., []byte("...")
+		formatter.builder.WriteString(value.Type().String())
+		bytes := make([]byte, value.Len())
+		for i := range bytes {
+			bytes[i] = byte(value.Index(i).Uint())
+		}
+		fmt.Fprintf(&formatter.builder, "(%q)", bytes)
+		return
+	}
+
+	formatter.formatTypeIf(showsType, value.Type())
+	if value.Len() == 0 {
+		formatter.builder.WriteString("{}")
+		return
+	}
+	formatter.builder.WriteString("{\n")
+	for i := 0; i < value.Len(); i++ {
+		formatter.builder.WriteString(indent + snapshotIndent)
+		formatter.format(value.Index(i), indent+snapshotIndent, false)
+		formatter.builder.WriteString(",\n")
+	}
+	formatter.builder.WriteString(indent + "}")
+}
+
+func (formatter *snapshotFormatter) formatMap(value reflect.Value, indent string, showsType bool) {
+	switch {
+	case value.IsNil():
+		formatter.formatNil(value, showsType)
+		return
+	case formatter.visiting[value.Pointer()]:
+		fmt.Fprintf(&formatter.builder, "(%s)(<cycle>)", value.Type())
+		return
+	}
+	formatter.visiting[value.Pointer()] = true
+	defer delete(formatter.visiting, value.Pointer())
+
+	formatter.formatTypeIf(showsType, value.Type())
+	if value.Len() == 0 {
+		formatter.builder.WriteString("{}")
+		return
+	}
+
+	type entry struct {
+		key          reflect.Value
+		formattedKey string
+	}
+	entries := []*entry{}
+	for _, key := range value.MapKeys() {
+		keyFormatter := &snapshotFormatter{visiting: formatter.visiting}
+		keyFormatter.format(key, indent+snapshotIndent, false)
+		entries = append(entries, &entry{key: key, formattedKey: keyFormatter.builder.String()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if isNaturallyOrdered(a.key) {
+			return isOrderedBefore(a.key, b.key)
+		}
+		return a.formattedKey < b.formattedKey
+	})
+
+	formatter.builder.WriteString("{\n")
+	for _, entry := range entries {
+		formatter.builder.WriteString(indent + snapshotIndent + entry.formattedKey + ": ")
+		formatter.format(value.MapIndex(entry.key), indent+snapshotIndent, false)
+		formatter.builder.WriteString(",\n")
+	}
+	formatter.builder.WriteString(indent + "}")
+}
+
+// isNaturallyOrdered returns whether the specified map key is a number or a
+// string; other keys are ordered by how they're formatted.
+func isNaturallyOrdered(key reflect.Value) bool {
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+func (formatter *snapshotFormatter) formatNil(value reflect.Value, showsType bool) {
+	if showsType {
+		fmt.Fprintf(&formatter.builder, "%s(nil)", value.Type())
+	} else {
+		formatter.builder.WriteString("nil")
+	}
+}
+
+func (formatter *snapshotFormatter) formatTypeIf(showsType bool, t reflect.Type) {
+	if showsType {
+		formatter.builder.WriteString(t.String())
+	}
+}
+
+// snapshotEntry is a value of a formatted snapshot, labeled by its path like
+// the differences of values are; e.g., Actual.Items[2].Price. Values that
+// span lines are represented by their first line; e.g., "[]string{...}".
+type snapshotEntry struct {
+	path  string
+	value string
+}
+
+// diffSnapshots compares the specified formatted snapshots value by value and
+// returns the values in which they differ, except for ones nested in other
+// differing values. It returns false if either snapshot isn't formatted by
+// formatSnapshot; e.g., if it was edited by hand.
+func diffSnapshots(actual, expected string) ([]*valueDifference, bool) {
+	actualEntries, isActualParsed := parseSnapshot(actual)
+	expectedEntries, isExpectedParsed := parseSnapshot(expected)
+	if !isActualParsed || !isExpectedParsed {
+		return nil, false
+	}
+
+	differences := []*valueDifference{}
+	report := func(path, actual, expected string) {
+		for _, difference := range differences {
+			if isNestedSnapshotPath(path, difference.path) {
+				return
+			}
+		}
+		differences = append(differences, &valueDifference{path: path, actual: actual, expected: expected})
+	}
+	expectedValues := map[string]string{}
+	for _, entry := range expectedEntries {
+		expectedValues[entry.path] = entry.value
+	}
+	actualValues := map[string]string{}
+	for _, entry := range actualEntries {
+		actualValues[entry.path] = entry.value
+		if expectedValue, ok := expectedValues[entry.path]; !ok {
+			report(entry.path, entry.value, missingValue)
+		} else if entry.value != expectedValue {
+			report(entry.path, entry.value, expectedValue)
+		}
+	}
+	for _, entry := range expectedEntries {
+		if _, ok := actualValues[entry.path]; !ok {
+			report(entry.path, missingValue, entry.value)
+		}
+	}
+	return differences, true
+}
+
+func isNestedSnapshotPath(path, parentPath string) bool {
+	return len(path) > len(parentPath) && strings.HasPrefix(path, parentPath) &&
+		strings.ContainsRune(".[", rune(path[len(parentPath)]))
+}
+
+// parseSnapshot splits the specified formatted snapshot into its values,
+// in order; it returns false if the snapshot isn't formatted by formatSnapshot.
+func parseSnapshot(snapshot string) ([]*snapshotEntry, bool) {
+	type container struct {
+		path   string
+		length int
+	}
+	entries, containers := []*snapshotEntry{}, []*container{}
+	for i, line := range strings.Split(snapshot, "\n") {
+		if i > 0 && len(containers) == 0 { // i.e., after the snapshot's value
+			return nil, false
+		}
+
+		path, text := rootPath, line
+		if len(containers) > 0 {
+			parent := containers[len(containers)-1]
+			closingIndent := strings.Repeat(snapshotIndent, len(containers)-1)
+			if line == closingIndent+"}" || line == closingIndent+"}," {
+				containers = containers[:len(containers)-1]
+				continue
+			}
+			indent := closingIndent + snapshotIndent
+			if !strings.HasPrefix(line, indent) || !strings.HasSuffix(line, ",") && !strings.HasSuffix(line, "{") {
+				return nil, false
+			}
+
+			text = strings.TrimSuffix(line[len(indent):], ",")
+			if label, value, ok := splitSnapshotLabel(text); !ok {
+				path = fmt.Sprintf("%s[%d]", parent.path, parent.length)
+			} else if isSnapshotFieldName(label) {
+				path, text = parent.path+"."+label, value
+			} else {
+				path, text = parent.path+"["+label+"]", value
+			}
+			parent.length++
+		}
+
+		if strings.HasSuffix(text, "{") {
+			containers = append(containers, &container{path: path})
+			text += "...}"
+		}
+		entries = append(entries, &snapshotEntry{path: path, value: text})
+	}
+	return entries, len(containers) == 0
+}
+
+// splitSnapshotLabel splits the specified text of a struct field or a map
+// entry into its label, which is a field name or a formatted key, and its
+// value; it returns false if the text has no label; i.e., it's an element of
+// a slice or an array.
+func splitSnapshotLabel(text string) (label, value string, ok bool) {
+	isQuoted := false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && isQuoted:
+			i++
+		case text[i] == '"':
+			isQuoted = !isQuoted
+		case !isQuoted && strings.HasPrefix(text[i:], ": "):
+			return text[:i], text[i+2:], true
+		}
+	}
+	return "", text, false
+}
+
+// isSnapshotFieldName returns whether the specified label is a field name
+// rather than a formatted map key, such as "a", 42, or true.
+func isSnapshotFieldName(label string) bool {
+	switch label {
+	case "true", "false", "nil", "NaN":
+		return false
+	}
+	for i, r := range label {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return label != ""
+}
@@ -0,0 +1,152 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type snapshotStatus int
+
+type snapshotNode struct {
+	Name string
+	Next *snapshotNode
+}
+
+type snapshotEvent struct {
+	at time.Time
+}
+
+type snapshotOrder struct {
+	ID       int
+	Status   snapshotStatus
+	Items    []string
+	Prices   map[string]float64
+	Metadata interface{}
+	Parent   *snapshotOrder
+	Payload  []byte
+	placed   time.Time
+	notify   chan string
+	Empty    struct{}
+}
+
+func TestFormatSnapshot(t *testing.T) {
+	cycle := &snapshotNode{Name: "a"}
+	cycle.Next = &snapshotNode{Name: "b", Next: cycle}
+	lines := func(values ...string) string { return strings.Join(values, "\n") }
+	cases := []struct {
+		id       string
+		value    interface{}
+		expected string
+	}{
+		{"nil", nil, "nil"},
+		{"int", 42, "42"},
+		{"int64", int64(42), "int64(42)"},
+		{"whole float", 1.0, "1.0"},
+		{"float32", float32(0.5), "float32(0.5)"},
+		{"string", "a\nb", `"a\nb"`},
+		{"named", snapshotStatus(2), "assert.snapshotStatus(2)"},
+		{"nil slice", []int(nil), "[]int(nil)"},
+		{"empty map", map[string]int{}, "map[string]int{}"},
+		{"map keys sorted naturally", map[int]bool{10: true, 9: false, -1: true},
+			lines("map[int]bool{", "    -1: true,", "    9: false,", "    10: true,", "}")},
+		{"interface elements", []interface{}{1, "a", nil, uint8(3)},
+			lines("[]interface {}{", "    1,", `    "a",`, "    nil,", "    uint8(3),", "}")},
+		{"cycle", cycle, lines(
+			"&assert.snapshotNode{",
+			`    Name: "a",`,
+			"    Next: &assert.snapshotNode{",
+			`        Name: "b",`,
+			"        Next: (*assert.snapshotNode)(<cycle>),",
+			"    },",
+			"}")},
+		{"unexported time by value", snapshotEvent{at: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)},
+			lines("assert.snapshotEvent{", `    at: time.Time("2019-05-01T00:00:00Z"),`, "}")},
+		{"unexported time in map", map[string]interface{}{"event": snapshotEvent{}}, lines(
+			"map[string]interface {}{",
+			`    "event": assert.snapshotEvent{`,
+			`        at: time.Time("0001-01-01T00:00:00Z"),`,
+			"    },",
+			"}")},
+		{"struct", &snapshotOrder{
+			ID:       7,
+			Status:   1,
+			Items:    []string{"apple", "pear"},
+			Prices:   map[string]float64{"pear": 2, "apple": 1.5},
+			Metadata: map[string]interface{}{"gift": true},
+			Payload:  []byte("{}"),
+			placed:   time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+			notify:   make(chan string),
+		}, lines(
+			"&assert.snapshotOrder{",
+			"    ID: 7,",
+			"    Status: assert.snapshotStatus(1),",
+			"    Items: []string{",
+			`        "apple",`,
+			`        "pear",`,
+			"    },",
+			"    Prices: map[string]float64{",
+			`        "apple": 1.5,`,
+			`        "pear": 2.0,`,
+			"    },",
+			"    Metadata: map[string]interface {}{",
+			`        "gift": true,`,
+			"    },",
+			"    Parent: (*assert.snapshotOrder)(nil),",
+			`    Payload: []uint8("{}"),`,
+			`    placed: time.Time("2019-05-01T12:00:00Z"),`,
+			"    notify: (chan string)(...),",
+			"    Empty: struct {}{},",
+			"}")},
+	}
+
+	for _, c := range cases {
+		For(t, c.id).ThatActualString(formatSnapshot(c.value)).Equals(c.expected)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	order := func(prices map[string]float64, items ...interface{}) string {
+		return formatSnapshot(&struct {
+			Items  []interface{}
+			Prices map[string]float64
+		}{items, prices})
+	}
+	cases := []struct {
+		id       string
+		actual   string
+		expected string
+		diffs    []string
+	}{
+		{"equal", order(nil, "a"), order(nil, "a"), []string{}},
+		{"scalars", "42", "13", []string{"Actual: 42 != 13"}},
+		{"elements", order(nil, "a", "b", "c"), order(nil, "a", "x"), []string{
+			`Actual.Items[1]: "b" != "x"`, `Actual.Items[2]: "c" != (missing)`}},
+		{"nested fields", order(nil, "a", &snapshotNode{Name: "b"}), order(nil, "a", &snapshotNode{Name: "c"}),
+			[]string{`Actual.Items[1].Name: "b" != "c"`}},
+		{"map entries", order(map[string]float64{"a: b": 1, "c": 2}), order(map[string]float64{"a: b": 3, "d": 2}),
+			[]string{`Actual.Prices["a: b"]: 1.0 != 3.0`, `Actual.Prices["c"]: 2.0 != (missing)`,
+				`Actual.Prices["d"]: (missing) != 2.0`}},
+		{"differing types", order(nil, []int{1}), order(nil, map[int]int{1: 1}),
+			[]string{"Actual.Items[0]: []int{...} != map[int]int{...}"}},
+		{"nil values", order(nil, "a"), order(map[string]float64{}, "a"),
+			[]string{"Actual.Prices: map[string]float64(nil) != map[string]float64{}"}},
+	}
+
+	for _, c := range cases {
+		differences, ok := diffSnapshots(c.actual, c.expected)
+		diffs := []string{}
+		for _, difference := range differences {
+			diffs = append(diffs, difference.String())
+		}
+		For(t, c.id).ThatActual(ok).IsTrue()
+		For(t, c.id).ThatActual(diffs).Equals(c.diffs)
+	}
+}
+
+func TestDiffSnapshotsEditedByHand(t *testing.T) {
+	for _, snapshot := range []string{"[]int{\n  1,\n}", "[]int{\n    1,\n", "42\n13", "[]int{\n    1\n}"} {
+		_, ok := diffSnapshots(formatSnapshot([]int{1}), snapshot)
+		For(t, snapshot).ThatActual(ok).IsFalse()
+	}
+}
@@ -0,0 +1,126 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SoftAssertionResult represents operations that may be performed on
+// the result of soft assertions; for example:
+//
+//     assert.For(t).Softly(func(a assert.TestContext) {
+//         a.ThatActual(response.Code).Equals(200)
+//     }).ThenRunOnFail(func(report *assert.SoftAssertionReport) {
+//         dump(response) // e.g., dump may log the response for analysis
+//     })
+type SoftAssertionResult interface {
+	// Passed returns true if all soft assertions passed.
+	Passed() bool
+
+	// Report returns the report of the soft assertions that failed.
+	Report() *SoftAssertionReport
+
+	// ThenRunOnFail performs the specified action if any soft assertion
+	// failed; in which case, it passes the report of the failed assertions
+	// as a parameter to the specified function.
+	// Returns the current SoftAssertionResult to allow for call-chaining.
+	ThenRunOnFail(action func(report *SoftAssertionReport)) SoftAssertionResult
+}
+
+// SoftAssertionReport reports the soft assertions that failed in a block;
+// see TestContext.Softly.
+type SoftAssertionReport struct {
+	// Failures lists the failed assertions in the order they failed.
+	Failures []*SoftAssertionFailure
+
+	lock sync.Mutex // serializes failures of assertions made in goroutines
+}
+
+// SoftAssertionFailure describes a soft assertion that failed.
+type SoftAssertionFailure struct {
+	// File and Line locate the failed assertion in the test file; Line is -1
+	// if the location is unknown.
+	File string
+	Line int
+
+	// Message is the failure message, prefixed by the test case parameters,
+	// if any.
+	Message string
+}
+
+type softAssertionResult struct {
+	report *SoftAssertionReport
+}
+
+func (testContext *testContext) Softly(assertions func(TestContext)) SoftAssertionResult {
+	testContext.Helper()
+	file, line := testContext.location() // must be set here to capture the right stack frame
+	softContext := *testContext
+	softContext.softReport = &SoftAssertionReport{}
+	result := &softAssertionResult{report: softContext.softReport}
+
+	defer func() { // reports failures even if the assertions panic or exit the goroutine
+		testContext.Helper()
+		if !result.Passed() { // the failures are already prefixed by the test case parameters, if any
+			reportingContext := *testContext
+			reportingContext.parameters = nil
+			reportingContext.errorf(file, line, "%s", result.report)
+		}
+	}()
+	assertions(&softContext)
+	return result
+}
+
+func (result *softAssertionResult) Passed() bool {
+	return len(result.report.failures()) == 0
+}
+
+func (result *softAssertionResult) Report() *SoftAssertionReport {
+	return result.report
+}
+
+func (result *softAssertionResult) ThenRunOnFail(action func(report *SoftAssertionReport)) SoftAssertionResult {
+	if !result.Passed() {
+		action(result.report)
+	}
+	return result
+}
+
+// String returns the number of failed assertions followed by their locations
+// and messages; for example:
+//     Soft assertions failed (2 failures).
+//     response_test.go:42: Value mismatch.
+//     Actual: 404
+//     Expected: 200
+//     response_test.go:43: ...
+func (report *SoftAssertionReport) String() string {
+	failures := report.failures()
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Soft assertions failed (%d %s).\n", len(failures), pluralize(len(failures), "failure"))
+	for _, failure := range failures {
+		builder.WriteString(failure.String())
+	}
+	return builder.String()
+}
+
+func (report *SoftAssertionReport) add(failure *SoftAssertionFailure) {
+	report.lock.Lock()
+	defer report.lock.Unlock()
+	report.Failures = append(report.Failures, failure)
+}
+
+func (report *SoftAssertionReport) failures() []*SoftAssertionFailure {
+	report.lock.Lock()
+	defer report.lock.Unlock()
+	return report.Failures
+}
+
+// String returns the location of the failed assertion followed by its
+// message; e.g., "response_test.go:42: Value mismatch...".
+func (failure *SoftAssertionFailure) String() string {
+	if failure.Line == noCallerInfoLineNumber {
+		return failure.Message
+	}
+	return fmt.Sprintf("%s:%d: %s", failure.File, failure.Line, failure.Message)
+}
@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"fmt"
+	"sync"
+)
+
+func ExampleTestContext_Softly_pass() {
+	result := For(t).Softly(func(a TestContext) {
+		a.ThatActual(42).Equals(42)
+		a.ThatActualString("foo").Equals("foo")
+	})
+
+	if result.Passed() && len(result.Report().Failures) == 0 {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleTestContext_Softly_fail() {
+	result := mockTestContextToAssert("case").Softly(func(a TestContext) {
+		fmt.Println("Assertions are made first...")
+		a.ThatActual(42).Equals(13)
+		a.ThatActual(true).IsTrue()
+		a.ThatActualString("foo").Equals("bar")
+		fmt.Println("...then failures are reported.")
+	})
+
+	if !result.Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// Assertions are made first...
+	// ...then failures are reported.
+	// file:3: Soft assertions failed (2 failures).
+	// file:3: [case] Value mismatch.
+	// Actual: 42
+	// Expected: 13
+	// file:3: [case] String mismatch.
+	// Actual: "foo"
+	// Expected: "bar"
+	// Assertion failed successfully!
+}
+
+func ExampleSoftAssertionResult_ThenRunOnFail() {
+	mockTestContextToAssert().Softly(func(a TestContext) {
+		var wait sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				a.ThatActual(i).IsNotNil()
+				a.ThatActual("done").DoesNotEqual("done")
+			}()
+		}
+		wait.Wait()
+	}).ThenRunOnFail(func(report *SoftAssertionReport) {
+		fmt.Println("Failures:", len(report.Failures))
+	})
+	// Output:
+	// file:3: Soft assertions failed (3 failures).
+	// file:3: Values are equal.
+	// Actual: "done"
+	// file:3: Values are equal.
+	// Actual: "done"
+	// file:3: Values are equal.
+	// Actual: "done"
+	// Failures: 3
+}
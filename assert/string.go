@@ -1,10 +1,20 @@
 package assert
 
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
 // AssertableString represents an under-test string that's expected to meet
 // certain criteria.
 type AssertableString interface {
 	// Equals asserts that the specified actual string equals the expected one.
 	// String values are compared byte-wise (implies case-sensetivity).
+	// If either string has multiple lines, a mismatch is reported as a
+	// unified line diff that makes invisible differences visible.
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	Equals(expected string) ValueAssertionResult
 
@@ -15,6 +25,66 @@ type AssertableString interface {
 	// IsNotEmpty asserts that the specified actual string is not empty.
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	IsNotEmpty() ValueAssertionResult
+
+	// Contains asserts that the specified actual string contains the
+	// specified substring.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Contains(substring string) ValueAssertionResult
+
+	// DoesNotContain asserts that the specified actual string doesn't
+	// contain the specified substring.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	DoesNotContain(substring string) ValueAssertionResult
+
+	// HasPrefix asserts that the specified actual string starts with the
+	// specified prefix.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasPrefix(prefix string) ValueAssertionResult
+
+	// HasSuffix asserts that the specified actual string ends with the
+	// specified suffix.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasSuffix(suffix string) ValueAssertionResult
+
+	// Matches asserts that the specified actual string matches the specified
+	// regular expression; e.g., `^v\d+\.\d+$`.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Matches(pattern string) ValueAssertionResult
+
+	// EqualsIgnoringCase asserts that the specified actual string equals the
+	// expected one under Unicode case-folding; e.g., "Go" equals "GO".
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	EqualsIgnoringCase(expected string) ValueAssertionResult
+
+	// EqualsIgnoringWhitespace asserts that the specified actual string
+	// equals the expected one once all whitespace is removed from both;
+	// e.g., "a b\n" equals " ab".
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	EqualsIgnoringWhitespace(expected string) ValueAssertionResult
+
+	// HasLength asserts that the specified actual string has the specified
+	// length in runes (not bytes); e.g., "héllo" has a length of 5.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	HasLength(length int) ValueAssertionResult
+
+	// ContainsCount asserts that the specified actual string contains the
+	// specified number of non-overlapping instances of the specified
+	// substring.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	ContainsCount(substring string, count int) ValueAssertionResult
+
+	// MatchesGoldenFile asserts that the specified actual string equals the
+	// content of the specified golden file of the test, which is stored in
+	// "_golden/<test name>/<name>" next to the test; subtests get their own
+	// directories; e.g., "_golden/TestRender/dark_mode/page.html". When
+	// tests are run with the -tester.update flag or with the TESTER_UPDATE
+	// environment variable set to a true value (e.g., "1"), the golden file
+	// is rewritten instead. Mismatches are reported as line diffs. The
+	// assertion fails if another test already used the same golden file,
+	// as names that aren't safe in file names may collide once characters
+	// are replaced; e.g., subtests named "a:b" and "a_b".
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MatchesGoldenFile(name string) ValueAssertionResult
 }
 
 type assertableString struct {
@@ -23,25 +93,135 @@ type assertableString struct {
 }
 
 func (actual *assertableString) Equals(expected string) ValueAssertionResult {
+	actual.testContext.Helper()
 	areEqual := actual.value == expected
-	if !areEqual {
+	if !areEqual && isMultiline(actual.value, expected) {
+		actual.testContext.decoratedErrorf("String mismatch.\n%s", formatLineDiff(actual.value, expected))
+	} else if !areEqual {
 		actual.testContext.decoratedErrorf("String mismatch.\nActual: %q\nExpected: %q\n", actual.value, expected)
 	}
-	return &valueAssertionResult{bool: areEqual, actual: actual.value, expected: expected}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
 }
 
 func (actual *assertableString) IsEmpty() ValueAssertionResult {
+	actual.testContext.Helper()
 	isEmpty := actual.value == ""
 	if !isEmpty {
 		actual.testContext.decoratedErrorf("String is not empty.\nActual: %q\n", actual.value)
 	}
-	return &valueAssertionResult{bool: isEmpty, actual: actual.value, expected: ""}
+	return actual.testContext.resultOf(isEmpty, actual.value, "")
 }
 
 func (actual *assertableString) IsNotEmpty() ValueAssertionResult {
+	actual.testContext.Helper()
 	isEmpty := actual.value == ""
 	if isEmpty {
 		actual.testContext.decoratedErrorf("String is empty.\n")
 	}
-	return &valueAssertionResult{bool: !isEmpty, actual: actual.value, expected: "<any non-empty string>"}
+	return actual.testContext.resultOf(!isEmpty, actual.value, "<any non-empty string>")
+}
+
+func (actual *assertableString) Contains(substring string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.satisfies(strings.Contains(actual.value, substring), "containing", substring)
+}
+
+func (actual *assertableString) DoesNotContain(substring string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.satisfies(!strings.Contains(actual.value, substring), "not containing", substring)
+}
+
+func (actual *assertableString) HasPrefix(prefix string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.satisfies(strings.HasPrefix(actual.value, prefix), "starting with", prefix)
+}
+
+func (actual *assertableString) HasSuffix(suffix string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.satisfies(strings.HasSuffix(actual.value, suffix), "ending with", suffix)
+}
+
+func (actual *assertableString) Matches(pattern string) ValueAssertionResult {
+	actual.testContext.Helper()
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid regular expression.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.value, pattern)
+	}
+	return actual.satisfies(regex.MatchString(actual.value), "matching regex", pattern)
+}
+
+func (actual *assertableString) EqualsIgnoringCase(expected string) ValueAssertionResult {
+	actual.testContext.Helper()
+	areEqual := strings.EqualFold(actual.value, expected)
+	if !areEqual {
+		actual.testContext.decoratedErrorf("String mismatch (ignoring case).\nActual: %q\nExpected: %q\n",
+			actual.value, expected)
+	}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
+}
+
+func (actual *assertableString) EqualsIgnoringWhitespace(expected string) ValueAssertionResult {
+	actual.testContext.Helper()
+	areEqual := removeWhitespace(actual.value) == removeWhitespace(expected)
+	if !areEqual {
+		actual.testContext.decoratedErrorf("String mismatch (ignoring whitespace).\nActual: %q\nExpected: %q\n",
+			actual.value, expected)
+	}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
+}
+
+func (actual *assertableString) HasLength(length int) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualLength := utf8.RuneCountInString(actual.value)
+	if actualLength != length {
+		actual.testContext.decoratedErrorf("String length mismatch.\nActual: %d\nExpected: %d\nString: %q\n",
+			actualLength, length, actual.value)
+	}
+	return actual.testContext.resultOf(actualLength == length, actualLength, length)
+}
+
+func (actual *assertableString) ContainsCount(substring string, count int) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualCount := strings.Count(actual.value, substring)
+	if actualCount != count {
+		actual.testContext.decoratedErrorf("Substring count mismatch.\nActual: %d\nExpected: %d\nString: %q\n"+
+			"Substring: %q\n", actualCount, count, actual.value, substring)
+	}
+	return actual.testContext.resultOf(actualCount == count, actualCount, count)
+}
+
+func (actual *assertableString) MatchesGoldenFile(name string) ValueAssertionResult {
+	actual.testContext.Helper()
+	return actual.testContext.matchesGoldenFile([]byte(actual.value), name)
+}
+
+// satisfies reports a failure that describes the expected string by the
+// specified relation to the specified pattern (e.g., "starting with" "foo"),
+// unless the actual string satisfies it.
+func (actual *assertableString) satisfies(satisfies bool, relation string, pattern string) ValueAssertionResult {
+	actual.testContext.Helper()
+	expected := "a string " + relation + " " + strconv.Quote(pattern)
+	if !satisfies {
+		actual.testContext.decoratedErrorf("String mismatch.\nActual: %q\nExpected: %s\n", actual.value, expected)
+	}
+	return actual.testContext.resultOf(satisfies, actual.value, expected)
+}
+
+func removeWhitespace(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+func isMultiline(values ...string) bool {
+	for _, value := range values {
+		if strings.Contains(strings.TrimSuffix(value, "\n"), "\n") {
+			return true
+		}
+	}
+	return false
 }
@@ -40,6 +40,27 @@ func ExampleAssertableString_Equals_fail() {
 	// Assertion failed successfully!
 }
 
+func ExampleAssertableString_Equals_failMultiline() {
+	rendered := "Dear Ann,\nYour order has shipped.  \nTracking:\t42\r\nQty:\u00a01\nRegards\n"
+	template := "Dear Ann,\nYour order has shipped.\nTracking: 42\nQty: 1\nRegards\n"
+	mockTestContextToAssert().ThatActualString(rendered).Equals(template)
+	// Output:
+	// file:3: String mismatch.
+	// --- Actual
+	// +++ Expected
+	// @@ -1,5 +1,5 @@
+	//  Dear Ann,
+	// -Your order has shipped.··
+	// -Tracking:→42␍
+	// -Qty:[U+00A0]1
+	// +Your order has shipped.
+	// +Tracking: 42
+	// +Qty: 1
+	//  Regards
+	// Legend: → tab, · trailing space, ␍ carriage return, [U+XXXX] invisible character
+	// First difference: line 2, column 24; actual ' ' (U+0020), expected '\n' (U+000A)
+}
+
 func ExampleAssertableString_IsEmpty_pass() {
 	if For(t).ThatActualString("").IsEmpty().Passed() {
 		fmt.Println("Passed!")
@@ -55,7 +76,7 @@ func ExampleAssertableString_IsEmpty_fail() {
 	// file:3: String is not empty.
 	// Actual: "foo"
 	// Diff:
-	// "foo" != ""
+	// Actual: "foo" != ""
 	// Assertion failed successfully!
 }
 
@@ -73,6 +94,148 @@ func ExampleAssertableString_IsNotEmpty_fail() {
 	// Output:
 	// file:3: String is empty.
 	// Diff:
-	// "" != "<any non-empty string>"
+	// Actual: "" != "<any non-empty string>"
 	// Assertion failed successfully!
 }
+
+func ExampleAssertableString_Contains_pass() {
+	if For(t).ThatActualString("Hello, World!").Contains("World").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_Contains_fail() {
+	mockTestContextToAssert().ThatActualString("Hello, World!").Contains("world")
+	// Output:
+	// file:3: String mismatch.
+	// Actual: "Hello, World!"
+	// Expected: a string containing "world"
+}
+
+func ExampleAssertableString_DoesNotContain_pass() {
+	if For(t).ThatActualString("user=ann").DoesNotContain("password").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_DoesNotContain_fail() {
+	mockTestContextToAssert().ThatActualString("user=ann password=secret").DoesNotContain("password")
+	// Output:
+	// file:3: String mismatch.
+	// Actual: "user=ann password=secret"
+	// Expected: a string not containing "password"
+}
+
+func ExampleAssertableString_HasPrefix_pass() {
+	if For(t).ThatActualString("https://example.com").HasPrefix("https://").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_HasPrefix_fail() {
+	mockTestContextToAssert().ThatActualString("http://example.com").HasPrefix("https://")
+	// Output:
+	// file:3: String mismatch.
+	// Actual: "http://example.com"
+	// Expected: a string starting with "https://"
+}
+
+func ExampleAssertableString_HasSuffix_pass() {
+	if For(t).ThatActualString("report.csv").HasSuffix(".csv").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_HasSuffix_fail() {
+	mockTestContextToAssert().ThatActualString("report.csv\n").HasSuffix(".csv")
+	// Output:
+	// file:3: String mismatch.
+	// Actual: "report.csv\n"
+	// Expected: a string ending with ".csv"
+}
+
+func ExampleAssertableString_Matches_pass() {
+	if For(t).ThatActualString("v1.12").Matches(`^v\d+\.\d+$`).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_Matches_fail() {
+	mockTestContextToAssert("mismatch").ThatActualString("v1.12-rc1").Matches(`^v\d+\.\d+$`)
+	mockTestContextToAssert("invalid pattern").ThatActualString("v1.12").Matches(`^v(\d+`)
+	// Output:
+	// file:3: [mismatch] String mismatch.
+	// Actual: "v1.12-rc1"
+	// Expected: a string matching regex "^v\\d+\\.\\d+$"
+	// file:3: [invalid pattern] Invalid regular expression.
+	// Error: error parsing regexp: missing closing ): `^v(\d+`
+}
+
+func ExampleAssertableString_EqualsIgnoringCase_pass() {
+	if For(t).ThatActualString("Content-Type").EqualsIgnoringCase("content-type").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_EqualsIgnoringCase_fail() {
+	mockTestContextToAssert().ThatActualString("Content-Type").EqualsIgnoringCase("Content-Length")
+	// Output:
+	// file:3: String mismatch (ignoring case).
+	// Actual: "Content-Type"
+	// Expected: "Content-Length"
+}
+
+func ExampleAssertableString_EqualsIgnoringWhitespace_pass() {
+	if For(t).ThatActualString("SELECT *\n  FROM users\n").EqualsIgnoringWhitespace("SELECT * FROM users").Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_EqualsIgnoringWhitespace_fail() {
+	query := "SELECT *\n  FROM user\n"
+	mockTestContextToAssert().ThatActualString(query).EqualsIgnoringWhitespace("SELECT * FROM users")
+	// Output:
+	// file:3: String mismatch (ignoring whitespace).
+	// Actual: "SELECT *\n  FROM user\n"
+	// Expected: "SELECT * FROM users"
+}
+
+func ExampleAssertableString_HasLength_pass() {
+	if For(t).ThatActualString("héllo").HasLength(5).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_HasLength_fail() {
+	mockTestContextToAssert().ThatActualString("héllo").HasLength(6)
+	// Output:
+	// file:3: String length mismatch.
+	// Actual: 5
+	// Expected: 6
+	// String: "héllo"
+}
+
+func ExampleAssertableString_ContainsCount_pass() {
+	if For(t).ThatActualString("a,b,c").ContainsCount(",", 2).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableString_ContainsCount_fail() {
+	mockTestContextToAssert().ThatActualString("a,b,,c").ContainsCount(",", 2)
+	// Output:
+	// file:3: Substring count mismatch.
+	// Actual: 3
+	// Expected: 2
+	// String: "a,b,,c"
+	// Substring: ","
+}
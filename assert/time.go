@@ -26,30 +26,33 @@ type assertableTime struct {
 }
 
 func (actual *assertableTime) Equals(expected *time.Time) ValueAssertionResult {
+	actual.testContext.Helper()
 	if expected == nil {
 		return actual.IsNil()
 	}
 	if actual.value == nil {
 		actual.testContext.decoratedErrorf("Time mismatch.\nActual was <nil>.\nExpected: %v\n", expected)
-		return &valueAssertionResult{bool: false, actual: actual.value, expected: expected}
+		return actual.testContext.resultOf(false, actual.value, expected)
 	}
 	areEqual := actual.value.Equal(*expected)
 	if !areEqual {
 		actual.testContext.decoratedErrorf("Time mismatch.\nActual: %v\nExpected: %v\n", actual.value, expected)
 	}
-	return &valueAssertionResult{bool: areEqual, actual: actual.value, expected: expected}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
 }
 
 func (actual *assertableTime) IsNil() ValueAssertionResult {
+	actual.testContext.Helper()
 	if actual.value != nil {
 		actual.testContext.decoratedErrorf("Actual time was not <nil>.\nActual: %v\n", actual.value)
 	}
-	return &valueAssertionResult{bool: actual.value == nil, actual: actual.value, expected: nil}
+	return actual.testContext.resultOf(actual.value == nil, actual.value, nil)
 }
 
 func (actual *assertableTime) IsNotNil() ValueAssertionResult {
+	actual.testContext.Helper()
 	if actual.value == nil {
 		actual.testContext.decoratedErrorf("Actual time was <nil>.\n")
 	}
-	return &valueAssertionResult{bool: actual.value != nil, actual: actual.value, expected: &anyOtherValue{}}
+	return actual.testContext.resultOf(actual.value != nil, actual.value, &anyOtherValue{})
 }
@@ -94,6 +94,6 @@ func ExampleAssertableTime_IsNotNil_fail() {
 	// Output:
 	// file:3: Actual time was <nil>.
 	// Diff:
-	// *time.Time != *assert.anyOtherValue
+	// Actual: *time.Time=<nil> != *assert.anyOtherValue=&{}
 	// Assertion failed successfully!
 }
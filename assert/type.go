@@ -30,6 +30,7 @@ const (
 )
 
 func (actual *assertableType) HidesTestHooks() {
+	actual.testContext.Helper()
 	if len(actual.Name()) == 0 || !ast.IsExported(actual.Name()) { // anonymous or unexported type
 		return
 	}
@@ -1,7 +1,6 @@
 package assert
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -19,6 +18,14 @@ type AssertableValue interface {
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	Equals(expected interface{}) ValueAssertionResult
 
+	// EqualsWith asserts that the specified actual value equals the expected
+	// one according to reflect.DeepEqual rules as adjusted by the specified
+	// options; for example:
+	//     assert.For(t).ThatActual(order).EqualsWith(expected, assert.IgnoreFields("ID"), assert.EquateTimes())
+	// On failure, the paths in which the values still differ are listed.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	EqualsWith(expected interface{}, options ...EqualityOption) ValueAssertionResult
+
 	// DoesNotEqual asserts that the specified actual value does not equal
 	// the unexpected one.
 	// See https://golang.org/pkg/reflect/#DeepEqual for equality rules.
@@ -43,9 +50,39 @@ type AssertableValue interface {
 
 	// MarshalsEquivalentJSON asserts that the specified actual value yields
 	// a JSON encoding equivalent to that of the specified expected value.
+	// Encodings are compared semantically; see AssertableJSON for details.
 	// See https://golang.org/pkg/encoding/json/#Marshal for encoding details.
 	// Returns a ValueAssertionResult that provides post-assert actions.
 	MarshalsEquivalentJSON(expected interface{}) ValueAssertionResult
+
+	// MarshalsEquivalentXML asserts that the specified actual value yields
+	// an XML encoding equivalent to that of the specified expected value.
+	// Encodings are compared canonically; see AssertableXML for details.
+	// See https://golang.org/pkg/encoding/xml/#Marshal for encoding details.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MarshalsEquivalentXML(expected interface{}) ValueAssertionResult
+
+	// MarshalsEquivalentYAML asserts that the specified actual value yields
+	// a YAML encoding equivalent to that of the specified expected value.
+	// Encodings are compared semantically; see AssertableYAML for details,
+	// including how values are encoded.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MarshalsEquivalentYAML(expected interface{}) ValueAssertionResult
+
+	// MatchesSnapshot asserts that the specified actual value, formatted
+	// deterministically in a Go-like syntax (with map entries sorted by key,
+	// pointers followed rather than printed as addresses, and cycles marked),
+	// equals the snapshot that was recorded for it. Snapshots are stored in
+	// "_snapshots/<test name>.snap" next to the test, keyed by the name of
+	// the (sub)test and the number of the snapshot in it, so a test may take
+	// many. When tests are run with the -tester.update flag or with the
+	// TESTER_UPDATE environment variable set to a true value (e.g., "1"),
+	// snapshots are recorded instead, and the ones that a test no longer
+	// takes are deleted. Mismatches are reported as the paths of the values
+	// that differ (e.g., Actual.Items[2].Price), followed by a line diff of
+	// the formatted values.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	MatchesSnapshot() ValueAssertionResult
 }
 
 type assertableValue struct {
@@ -60,62 +97,133 @@ type assertableValue struct {
 type anyOtherValue struct{}
 
 func (actual *assertableValue) Equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
 	areEqual := reflect.DeepEqual(actual.value, expected)
 	if !areEqual {
 		actual.printValueMismatchError(expected)
 	}
-	return &valueAssertionResult{bool: areEqual, actual: actual.value, expected: expected}
+	return actual.testContext.resultOf(areEqual, actual.value, expected)
 }
 
 func (actual *assertableValue) printValueMismatchError(expected interface{}) {
-	if fmt.Sprint(actual.value) == fmt.Sprint(expected) {
+	actual.testContext.Helper()
+	differences := diffValues(actual.value, expected, newEqualityOptions())
+	switch {
+	case reflect.TypeOf(actual.value) != reflect.TypeOf(expected) && fmt.Sprint(actual.value) == fmt.Sprint(expected):
 		actual.testContext.decoratedErrorf(
 			"Type mismatch.\nActual: %T=%v\nExpected: %T=%v\n", actual.value, actual.value, expected, expected)
-	} else {
+	case len(differences) == 0 || len(differences) == 1 && differences[0].path == rootPath:
 		actual.testContext.decoratedErrorf("Value mismatch.\nActual: %#v\nExpected: %#v\n", actual.value, expected)
+	default:
+		actual.testContext.decoratedErrorf("%s", summarizeDifferences(actual.value, differences))
+	}
+}
+
+func (actual *assertableValue) EqualsWith(expected interface{}, options ...EqualityOption) ValueAssertionResult {
+	actual.testContext.Helper()
+	differences := diffValues(actual.value, expected, newEqualityOptions(options...))
+	if len(differences) > 0 {
+		actual.testContext.decoratedErrorf("%s", summarizeDifferences(actual.value, differences))
 	}
+	return actual.testContext.resultOf(len(differences) == 0, actual.value, expected)
 }
 
 func (actual *assertableValue) DoesNotEqual(value interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
 	areEqual := reflect.DeepEqual(actual.value, value)
 	if areEqual {
 		actual.testContext.decoratedErrorf("Values are equal.\nActual: %#v\n", actual.value)
 	}
-	return &valueAssertionResult{bool: !areEqual, actual: actual.value, expected: &anyOtherValue{}}
+	return actual.testContext.resultOf(!areEqual, actual.value, &anyOtherValue{})
 }
 
 func (actual *assertableValue) IsNil() ValueAssertionResult {
+	actual.testContext.Helper()
 	return actual.Equals(nil)
 }
 
 func (actual *assertableValue) IsNotNil() ValueAssertionResult {
+	actual.testContext.Helper()
 	return actual.DoesNotEqual(nil)
 }
 
 func (actual *assertableValue) IsFalse() ValueAssertionResult {
+	actual.testContext.Helper()
 	return actual.Equals(false)
 }
 
 func (actual *assertableValue) IsTrue() ValueAssertionResult {
+	actual.testContext.Helper()
 	return actual.Equals(true)
 }
 
 func (actual *assertableValue) MarshalsEquivalentJSON(expected interface{}) ValueAssertionResult {
-	var expectedBytes []byte
-	actualBytes, err := json.MarshalIndent(actual.value, "", jsonIndent)
-	if err != nil {
-		goto mismatch
+	actual.testContext.Helper()
+	actualBytes, actualErr := json.MarshalIndent(actual.value, "", jsonIndent)
+	expectedBytes, expectedErr := json.MarshalIndent(expected, "", jsonIndent)
+	var differences []*valueDifference
+	if actualErr == nil && expectedErr == nil {
+		actualJSON, _ := decodeJSON(actualBytes)
+		expectedJSON, _ := decodeJSON(expectedBytes)
+		if differences = diffJSON("", actualJSON, expectedJSON, false); len(differences) == 0 {
+			return actual.testContext.resultOf(true, actual.value, expected)
+		}
+	}
+
+	message := "JSON mismatch.\n"
+	if actualErr == nil && expectedErr == nil {
+		message += fmt.Sprintf("Actual: %s\nExpected: %s\n", actualBytes, expectedBytes)
+	} else if actualErr == nil {
+		message += fmt.Sprintf("Error: %v\n", expectedErr)
+	} else {
+		message += fmt.Sprintf("Error: %v\n", actualErr)
+	}
+	actual.testContext.decoratedErrorf("%s", message+formatDifferences(differences))
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+func (actual *assertableValue) MarshalsEquivalentXML(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualXML, actualRoot, err := marshalToXMLElement(actual.value)
+	expectedXML, expectedRoot, expectedErr := marshalToXMLElement(expected)
+	var differences []*valueDifference
+	if err == nil && expectedErr == nil {
+		if differences = diffXML("/"+actualRoot.name.Local, actualRoot, expectedRoot); len(differences) == 0 {
+			return actual.testContext.resultOf(true, actual.value, expected)
+		}
+	}
+
+	message := fmt.Sprintf("XML mismatch.\nActual: %s\nExpected: %s\n", actualXML, expectedXML)
+	if err == nil {
+		err = expectedErr
 	}
-	expectedBytes, err = json.MarshalIndent(expected, "", jsonIndent)
 	if err != nil {
-		goto mismatch
+		message += fmt.Sprintf("Error: %v\n", err)
 	}
-	if !bytes.Equal(actualBytes, expectedBytes) {
-		goto mismatch
+	actual.testContext.decoratedErrorf("%s", message+formatDifferences(differences))
+	return actual.testContext.resultOf(false, actual.value, expected)
+}
+
+func (actual *assertableValue) MarshalsEquivalentYAML(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	actualYAML, actualValue, err := marshalToYAML(actual.value)
+	expectedYAML, expectedValue, expectedErr := marshalToYAML(expected)
+	var differences []*valueDifference
+	if err == nil && expectedErr == nil {
+		if differences = diffJSON("", actualValue, expectedValue, false); len(differences) == 0 {
+			return actual.testContext.resultOf(true, actual.value, expected)
+		}
 	}
-	return &valueAssertionResult{bool: true, actual: actual.value, expected: expected}
 
-mismatch:
-	actual.testContext.decoratedErrorf("JSON mismatch.\nActual: %s\nExpected: %s\n", actualBytes, expectedBytes)
-	return &valueAssertionResult{bool: false, actual: actual.value, expected: expected}
+	message := "YAML mismatch.\n"
+	if err == nil && expectedErr == nil {
+		message += "Actual:\n" + indentLines(actualYAML, yamlIndent) +
+			"Expected:\n" + indentLines(expectedYAML, yamlIndent)
+	} else if err == nil {
+		message += fmt.Sprintf("Error: %v\n", expectedErr)
+	} else {
+		message += fmt.Sprintf("Error: %v\n", err)
+	}
+	actual.testContext.decoratedErrorf("%s", message+formatDifferences(differences))
+	return actual.testContext.resultOf(false, actual.value, expected)
 }
@@ -69,7 +69,7 @@ func ExampleAssertableValue_DoesNotEqual_fail() {
 	// file:3: Values are equal.
 	// Actual: 42
 	// Diff:
-	// int != *assert.anyOtherValue
+	// Actual: int=42 != *assert.anyOtherValue=&{}
 	// Assertion failed successfully!
 }
 
@@ -106,7 +106,7 @@ func ExampleAssertableValue_IsNotNil_fail() {
 	// file:3: Values are equal.
 	// Actual: <nil>
 	// Diff:
-	// nil != &assert.anyOtherValue{}
+	// Actual: nil != &assert.anyOtherValue{}
 	// Assertion failed successfully!
 }
 
@@ -168,9 +168,89 @@ func ExampleAssertableValue_MarshalsEquivalentJSON_fail() {
 	if !mockTestContextToAssert().ThatActual(nil).MarshalsEquivalentJSON("").Passed() {
 		fmt.Println("Assertion failed successfully!")
 	}
+	if !mockTestContextToAssert().ThatActual(make(chan int)).MarshalsEquivalentJSON(nil).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
 	// Output:
 	// file:3: JSON mismatch.
 	// Actual: null
 	// Expected: ""
+	// (root): null != ""
+	// Assertion failed successfully!
+	// file:3: JSON mismatch.
+	// Error: json: unsupported type: chan int
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableValue_MarshalsEquivalentXML_pass() {
+	type user struct {
+		XMLName struct{} `xml:"user"`
+		Name    string   `xml:"name,attr"`
+	}
+
+	if For(t).ThatActual(&user{Name: "Ann"}).MarshalsEquivalentXML(user{Name: "Ann"}).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableValue_MarshalsEquivalentXML_fail() {
+	type user struct {
+		XMLName struct{} `xml:"user"`
+		Name    string   `xml:"name,attr"`
+	}
+	type person struct {
+		XMLName struct{} `xml:"user"`
+		Name    string   `xml:"name"`
+	}
+
+	if !mockTestContextToAssert().ThatActual(&user{Name: "Ann"}).MarshalsEquivalentXML(&person{Name: "Ann"}).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: XML mismatch.
+	// Actual: <user name="Ann"></user>
+	// Expected: <user>
+	//   <name>Ann</name>
+	// </user>
+	// /user/@name: "Ann" != (missing)
+	// /user/name: (missing) != <name>Ann</name>
 	// Assertion failed successfully!
 }
+
+func ExampleAssertableValue_MarshalsEquivalentYAML_pass() {
+	type config struct {
+		Name  string   `yaml:"name"`
+		Hosts []string `yaml:"hosts,omitempty"`
+	}
+
+	if For(t).ThatActual(&config{Name: "web"}).MarshalsEquivalentYAML(map[string]string{"name": "web"}).Passed() {
+		fmt.Println("Passed!")
+	}
+	// Output: Passed!
+}
+
+func ExampleAssertableValue_MarshalsEquivalentYAML_fail() {
+	type config struct {
+		Name  string   `yaml:"name"`
+		Hosts []string `yaml:"hosts,omitempty"`
+	}
+
+	actual := &config{Name: "web", Hosts: []string{"a", "b"}}
+	mockTestContextToAssert().ThatActual(actual).MarshalsEquivalentYAML(&config{Name: "web", Hosts: []string{"a"}})
+	mockTestContextToAssert().ThatActual(actual).MarshalsEquivalentYAML(func() {})
+	// Output:
+	// file:3: YAML mismatch.
+	// Actual:
+	//   name: web
+	//   hosts:
+	//     - a
+	//     - b
+	// Expected:
+	//   name: web
+	//   hosts:
+	//     - a
+	// /hosts/1: "b" != (missing)
+	// file:3: YAML mismatch.
+	// Error: cannot marshal type: func()
+}
@@ -0,0 +1,326 @@
+package assert
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	xmlIndent            = "  "
+	xmlNamespaceAttrName = "xmlns"
+)
+
+// AssertableXML represents an under-test XML document that's expected to meet
+// certain criteria. Documents are compared canonically: namespace prefixes
+// are resolved to their namespace URIs, attribute order, comments, processing
+// instructions, and whitespace around text are ignored, and adjacent text and
+// CDATA sections are merged. Differences are reported as XPath-like paths;
+// e.g., /Envelope/Body/User[2]/@id.
+type AssertableXML interface {
+	// Equals asserts that the specified actual XML document is equivalent to
+	// the expected one, which is specified as a []byte, a string, or an
+	// io.Reader.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Equals(expected interface{}) ValueAssertionResult
+
+	// EqualsValue asserts that the specified actual XML document is
+	// equivalent to the XML encoding of the expected value.
+	// See https://golang.org/pkg/encoding/xml/#Marshal for encoding details.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	EqualsValue(expected interface{}) ValueAssertionResult
+}
+
+type assertableXML struct {
+	testContext *testContext
+	root        *xmlElement
+	err         error
+}
+
+// xmlElement is the canonical form of an XML element; its name and its
+// attributes' names have their namespace URIs rather than their prefixes,
+// its attributes exclude namespace declarations and are sorted, and its
+// children are *xmlElement values and trimmed, non-empty text strings.
+type xmlElement struct {
+	name       xml.Name
+	attributes []xml.Attr
+	children   []interface{}
+}
+
+func newAssertableXML(testContext *testContext, document interface{}) *assertableXML {
+	root, err := decodeXML(document)
+	return &assertableXML{testContext: testContext, root: root, err: err}
+}
+
+func (actual *assertableXML) Equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	expectedRoot, err := decodeXML(expected)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid expected XML.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.root, expected)
+	}
+	return actual.equals(expectedRoot)
+}
+
+func (actual *assertableXML) EqualsValue(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	_, expectedRoot, err := marshalToXMLElement(expected)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid expected XML.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.root, expected)
+	}
+	return actual.equals(expectedRoot)
+}
+
+func (actual *assertableXML) equals(expected *xmlElement) ValueAssertionResult {
+	actual.testContext.Helper()
+	if actual.err != nil {
+		actual.testContext.decoratedErrorf("Invalid actual XML.\nError: %v\n", actual.err)
+		return actual.testContext.resultOf(false, actual.root, expected)
+	}
+	differences := diffXML("/"+actual.root.name.Local, actual.root, expected)
+	if len(differences) > 0 {
+		actual.testContext.decoratedErrorf("XML mismatch (%d %s).\n%s",
+			len(differences), pluralize(len(differences), "difference"), formatDifferences(differences))
+	}
+	return actual.testContext.resultOf(len(differences) == 0, actual.root, expected)
+}
+
+// decodeXML decodes the specified XML document, which can be a []byte,
+// a string, or an io.Reader, into the canonical form of its root element.
+func decodeXML(document interface{}) (*xmlElement, error) {
+	reader, err := documentReader(document, "XML")
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := xml.NewDecoder(reader)
+	var root *xmlElement
+	elements := []*xmlElement{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch typed := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: typed.Name, attributes: canonicalXMLAttributes(typed.Attr)}
+			if len(elements) > 0 {
+				parent := elements[len(elements)-1]
+				parent.children = append(parent.children, element)
+			} else if root != nil {
+				return nil, fmt.Errorf("multiple root elements <%s> and <%s>", root.name.Local, typed.Name.Local)
+			} else {
+				root = element
+			}
+			elements = append(elements, element)
+		case xml.EndElement:
+			elements[len(elements)-1].trimText()
+			elements = elements[:len(elements)-1]
+		case xml.CharData:
+			if len(elements) > 0 {
+				elements[len(elements)-1].appendText(string(typed))
+			} else if strings.TrimSpace(string(typed)) != "" {
+				return nil, fmt.Errorf("text %q outside the root element", strings.TrimSpace(string(typed)))
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+func marshalToXMLElement(value interface{}) ([]byte, *xmlElement, error) {
+	encoded, err := xml.MarshalIndent(value, "", xmlIndent)
+	if err != nil {
+		return encoded, nil, err
+	}
+	root, err := decodeXML(encoded)
+	return encoded, root, err
+}
+
+func canonicalXMLAttributes(attributes []xml.Attr) []xml.Attr {
+	canonical := []xml.Attr{}
+	for _, attribute := range attributes {
+		if attribute.Name.Space != xmlNamespaceAttrName &&
+			!(attribute.Name.Space == "" && attribute.Name.Local == xmlNamespaceAttrName) {
+			canonical = append(canonical, attribute)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return formatXMLName(canonical[i].Name) < formatXMLName(canonical[j].Name)
+	})
+	return canonical
+}
+
+// appendText appends the specified text to the element, merging it with its
+// last child if that's text as well.
+func (element *xmlElement) appendText(text string) {
+	if last := len(element.children) - 1; last >= 0 {
+		if previous, ok := element.children[last].(string); ok {
+			element.children[last] = previous + text
+			return
+		}
+	}
+	element.children = append(element.children, text)
+}
+
+// trimText trims the element's text children and drops the empty ones.
+func (element *xmlElement) trimText() {
+	children := element.children[:0]
+	for _, child := range element.children {
+		if text, ok := child.(string); ok {
+			if child = strings.TrimSpace(text); child == "" {
+				continue
+			}
+		}
+		children = append(children, child)
+	}
+	element.children = children
+}
+
+// diffXML compares the specified elements, whose path is specified, and
+// returns the differences labeled by their XPath-like paths. Children are
+// compared by their positions.
+func diffXML(path string, actual, expected *xmlElement) []*valueDifference {
+	if actual.name != expected.name {
+		return []*valueDifference{{path, formatXMLName(actual.name), formatXMLName(expected.name)}}
+	}
+
+	differences := []*valueDifference{}
+	actualAttributes, expectedAttributes := actual.attributeValues(), expected.attributeValues()
+	for _, name := range unionOfXMLAttributeNames(actual, expected) {
+		attributePath := path + "/@" + formatXMLName(name)
+		actualValue, isInActual := actualAttributes[name]
+		expectedValue, isInExpected := expectedAttributes[name]
+		switch {
+		case !isInActual:
+			differences = append(differences,
+				&valueDifference{attributePath, missingValue, strconv.Quote(expectedValue)})
+		case !isInExpected:
+			differences = append(differences, &valueDifference{attributePath, strconv.Quote(actualValue), missingValue})
+		case actualValue != expectedValue:
+			differences = append(differences,
+				&valueDifference{attributePath, strconv.Quote(actualValue), strconv.Quote(expectedValue)})
+		}
+	}
+
+	actualPaths, expectedPaths := xmlChildPaths(path, actual.children), xmlChildPaths(path, expected.children)
+	for i := 0; i < len(actual.children) || i < len(expected.children); i++ {
+		if i >= len(actual.children) {
+			differences = append(differences,
+				&valueDifference{expectedPaths[i], missingValue, formatXMLNode(expected.children[i])})
+			continue
+		} else if i >= len(expected.children) {
+			differences = append(differences,
+				&valueDifference{actualPaths[i], formatXMLNode(actual.children[i]), missingValue})
+			continue
+		}
+
+		actualElement, isActualElement := actual.children[i].(*xmlElement)
+		expectedElement, isExpectedElement := expected.children[i].(*xmlElement)
+		switch {
+		case isActualElement && isExpectedElement:
+			differences = append(differences, diffXML(actualPaths[i], actualElement, expectedElement)...)
+		case actual.children[i] != expected.children[i]:
+			differences = append(differences, &valueDifference{
+				actualPaths[i], formatXMLNode(actual.children[i]), formatXMLNode(expected.children[i])})
+		}
+	}
+	return differences
+}
+
+func (element *xmlElement) attributeValues() map[xml.Name]string {
+	values := make(map[xml.Name]string, len(element.attributes))
+	for _, attribute := range element.attributes {
+		values[attribute.Name] = attribute.Value
+	}
+	return values
+}
+
+func unionOfXMLAttributeNames(actual, expected *xmlElement) []xml.Name {
+	names, isAdded := []xml.Name{}, map[xml.Name]bool{}
+	for _, attribute := range append(append([]xml.Attr{}, actual.attributes...), expected.attributes...) {
+		if !isAdded[attribute.Name] {
+			names, isAdded[attribute.Name] = append(names, attribute.Name), true
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return formatXMLName(names[i]) < formatXMLName(names[j]) })
+	return names
+}
+
+// xmlChildPaths returns the XPath-like paths of the specified children of the
+// element whose path is specified; e.g., /Users/User[2] and /Users/text().
+// Positional predicates are added only to the paths of children that share
+// their names with their siblings.
+func xmlChildPaths(path string, children []interface{}) []string {
+	steps := make([]string, len(children))
+	for i, child := range children {
+		steps[i] = "text()"
+		if element, ok := child.(*xmlElement); ok {
+			steps[i] = element.name.Local
+		}
+	}
+
+	counts, positions := map[string]int{}, map[string]int{}
+	for _, step := range steps {
+		counts[step]++
+	}
+	paths := make([]string, len(children))
+	for i, step := range steps {
+		positions[step]++
+		paths[i] = path + "/" + step
+		if counts[step] > 1 {
+			paths[i] += fmt.Sprintf("[%d]", positions[step])
+		}
+	}
+	return paths
+}
+
+// formatXMLName formats the specified name in Clark notation; i.e.,
+// {namespace}local, or just local if it has no namespace.
+func formatXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// formatXMLNode formats the specified element in compact XML using local
+// names, or the specified text as a quoted string.
+func formatXMLNode(node interface{}) string {
+	element, ok := node.(*xmlElement)
+	if !ok {
+		return strconv.Quote(fmt.Sprint(node))
+	}
+
+	var builder strings.Builder
+	builder.WriteString("<" + element.name.Local)
+	for _, attribute := range element.attributes {
+		builder.WriteString(" " + attribute.Name.Local + `="`)
+		xml.EscapeText(&builder, []byte(attribute.Value))
+		builder.WriteString(`"`)
+	}
+	if len(element.children) == 0 {
+		builder.WriteString("/>")
+		return builder.String()
+	}
+	builder.WriteString(">")
+	for _, child := range element.children {
+		if text, ok := child.(string); ok {
+			xml.EscapeText(&builder, []byte(text))
+		} else {
+			builder.WriteString(formatXMLNode(child))
+		}
+	}
+	builder.WriteString("</" + element.name.Local + ">")
+	return builder.String()
+}
@@ -0,0 +1,105 @@
+package assert
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+func ExampleAssertableXML_Equals_pass() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"attribute order", `<user id="1" role="admin"/>`, `<user role="admin" id="1"></user>`},
+		{"whitespace", "<users>\n  <user> Ann </user>\n</users>", "<users><user>Ann</user></users>"},
+		{"namespace prefixes",
+			`<s:Envelope xmlns:s="urn:soap"><s:Body><u:user xmlns:u="urn:users" u:id="1"/></s:Body></s:Envelope>`,
+			`<Envelope xmlns="urn:soap"><Body><user xmlns:x="urn:users" xmlns="urn:users" x:id="1"/></Body>` +
+				`</Envelope>`},
+		{"comments and CDATA", strings.NewReader(`<?xml version="1.0"?><!-- users --><user>A<![CDATA[nn]]></user>`),
+			[]byte(`<user>Ann</user>`)},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualXML(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: attribute order
+	// Passed: whitespace
+	// Passed: namespace prefixes
+	// Passed: comments and CDATA
+}
+
+func ExampleAssertableXML_Equals_fail() {
+	actual := `<s:Envelope xmlns:s="urn:soap"><s:Body>
+		<user id="1" role="admin">Ann</user>
+		<user id="2">Bob</user>
+		<total>2</total>
+	</s:Body></s:Envelope>`
+	expected := `<s:Envelope xmlns:s="urn:soap"><s:Body>
+		<user id="1">Ann</user>
+		<user id="3">Bobby</user>
+		<s:total>2</s:total>
+		<next/>
+	</s:Body></s:Envelope>`
+
+	if !mockTestContextToAssert().ThatActualXML(actual).Equals(expected).Passed() {
+		fmt.Println("Assertion failed successfully!")
+	}
+	// Output:
+	// file:3: XML mismatch (5 differences).
+	// /Envelope/Body/user[1]/@role: "admin" != (missing)
+	// /Envelope/Body/user[2]/@id: "2" != "3"
+	// /Envelope/Body/user[2]/text(): "Bob" != "Bobby"
+	// /Envelope/Body/total: total != {urn:soap}total
+	// /Envelope/Body/next: (missing) != <next/>
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableXML_Equals_invalidXML() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"unclosed element", "<user>", "<user/>"},
+		{"multiple roots", "<user/><user/>", "<user/>"},
+		{"no root", "<!-- user -->", "<user/>"},
+		{"invalid expected", "<user/>", 42},
+	}
+
+	for _, c := range cases {
+		mockTestContextToAssert(c.id).ThatActualXML(c.actual).Equals(c.expected)
+	}
+	// Output:
+	// file:3: [unclosed element] Invalid actual XML.
+	// Error: XML syntax error on line 1: unexpected EOF
+	// file:3: [multiple roots] Invalid actual XML.
+	// Error: multiple root elements <user> and <user>
+	// file:3: [no root] Invalid actual XML.
+	// Error: no root element
+	// file:3: [invalid expected] Invalid expected XML.
+	// Error: unsupported XML document type int; expected []byte, string, or io.Reader
+}
+
+func ExampleAssertableXML_EqualsValue() {
+	type user struct {
+		XMLName xml.Name `xml:"user"`
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+
+	if For(t).ThatActualXML(`<user id="1"><name>Ann</name></user>`).EqualsValue(&user{ID: 1, Name: "Ann"}).Passed() {
+		fmt.Println("Passed!")
+	}
+
+	mockTestContextToAssert().ThatActualXML(`<user id="1"><name>Ann</name></user>`).EqualsValue(&user{Name: "Ann"})
+	// Output:
+	// Passed!
+	// file:3: XML mismatch (1 difference).
+	// /user/@id: "1" != "0"
+}
@@ -0,0 +1,212 @@
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const yamlIndent = "  "
+
+// AssertableYAML represents an under-test YAML stream that's expected to meet
+// certain criteria. Streams are compared semantically, document by document:
+// mapping key order, comments, quoting, and block versus flow styles are
+// ignored, aliases and merge keys (<<) are expanded, and numbers are compared
+// by value (e.g., 0x10 equals 16). Differences are reported as JSON Pointer
+// paths (RFC 6901), prefixed by the document number if either stream has
+// several documents.
+//
+// Streams are decoded by gopkg.in/yaml.v3, which rejects invalid YAML (e.g.,
+// tab indentation or duplicate mapping keys) rather than guessing. Mapping
+// keys are compared as strings.
+type AssertableYAML interface {
+	// Equals asserts that the specified actual YAML stream is equivalent to
+	// the expected one, which is specified as a []byte, a string, or an
+	// io.Reader; for example:
+	//     assert.For(t).ThatActualYAML(manifest).Equals("kind: Pod\nmetadata: {name: web}\n")
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	Equals(expected interface{}) ValueAssertionResult
+
+	// EqualsValue asserts that the specified actual YAML stream consists of
+	// a single document that's equivalent to the YAML encoding of the
+	// expected value, as encoded by gopkg.in/yaml.v3: struct fields are named
+	// by their yaml tags or else their lowercased names, the omitempty,
+	// inline, and "-" tag options are honored, and yaml.Marshaler and
+	// encoding.TextMarshaler implementations are used.
+	// Returns a ValueAssertionResult that provides post-assert actions.
+	EqualsValue(expected interface{}) ValueAssertionResult
+}
+
+type assertableYAML struct {
+	testContext *testContext
+	documents   []interface{}
+	err         error
+}
+
+func newAssertableYAML(testContext *testContext, document interface{}) *assertableYAML {
+	documents, err := decodeYAML(document)
+	return &assertableYAML{testContext: testContext, documents: documents, err: err}
+}
+
+func (actual *assertableYAML) Equals(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	expectedDocuments, err := decodeYAML(expected)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid expected YAML.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.documents, expected)
+	}
+	return actual.equals(expectedDocuments)
+}
+
+func (actual *assertableYAML) EqualsValue(expected interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	_, expectedValue, err := marshalToYAML(expected)
+	if err != nil {
+		actual.testContext.decoratedErrorf("Invalid expected YAML.\nError: %v\n", err)
+		return actual.testContext.resultOf(false, actual.documents, expected)
+	}
+	return actual.equals([]interface{}{expectedValue})
+}
+
+func (actual *assertableYAML) equals(expected []interface{}) ValueAssertionResult {
+	actual.testContext.Helper()
+	if actual.err != nil {
+		actual.testContext.decoratedErrorf("Invalid actual YAML.\nError: %v\n", actual.err)
+		return actual.testContext.resultOf(false, actual.documents, expected)
+	}
+	differences := diffYAML(actual.documents, expected)
+	if len(differences) > 0 {
+		actual.testContext.decoratedErrorf("YAML mismatch (%d %s).\n%s",
+			len(differences), pluralize(len(differences), "difference"), formatDifferences(differences))
+	}
+	return actual.testContext.resultOf(len(differences) == 0, actual.documents, expected)
+}
+
+// diffYAML compares the specified decoded YAML streams document by document.
+func diffYAML(actual, expected []interface{}) []*valueDifference {
+	if len(actual) == 1 && len(expected) == 1 {
+		return diffJSON("", actual[0], expected[0], false)
+	}
+
+	differences := []*valueDifference{}
+	for i := 0; i < len(actual) || i < len(expected); i++ {
+		documentPointer := fmt.Sprintf("(document %d)", i+1)
+		switch {
+		case i >= len(actual):
+			differences = append(differences, newJSONDifference(documentPointer, missingValue, expected[i]))
+		case i >= len(expected):
+			differences = append(differences, newJSONDifference(documentPointer, actual[i], missingValue))
+		default:
+			differences = append(differences, diffJSON(documentPointer, actual[i], expected[i], false)...)
+		}
+	}
+	return differences
+}
+
+// decodeYAML decodes the documents of the specified YAML stream, which can be
+// a []byte, a string, or an io.Reader, into generic values like decodeJSON
+// does: mappings become map[string]interface{} values, sequences
+// []interface{}, integers and finite floats json.Number values, and other
+// scalars nil, bool, float64 (i.e., infinities and NaN), or string values.
+func decodeYAML(stream interface{}) ([]interface{}, error) {
+	reader, err := documentReader(stream, "YAML")
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(reader)
+	documents := []interface{}{}
+	for {
+		var document interface{}
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return documents, nil
+		} else if err != nil {
+			return nil, err
+		}
+		documents = append(documents, toGenericYAMLValue(document))
+	}
+}
+
+// toGenericYAMLValue converts the specified value, as decoded by yaml.v3,
+// into the generic value that decodeYAML returns.
+func toGenericYAMLValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, element := range typed {
+			typed[key] = toGenericYAMLValue(element)
+		}
+	case map[interface{}]interface{}: // e.g., with integer keys
+		mapping := make(map[string]interface{}, len(typed))
+		for key, element := range typed {
+			mapping[formatYAMLKey(key)] = toGenericYAMLValue(element)
+		}
+		return mapping
+	case []interface{}:
+		for i, element := range typed {
+			typed[i] = toGenericYAMLValue(element)
+		}
+	case int:
+		return json.Number(strconv.Itoa(typed))
+	case int64:
+		return json.Number(strconv.FormatInt(typed, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(typed, 10))
+	case float64:
+		return toYAMLFloat(typed)
+	case time.Time: // i.e., explicitly tagged !!timestamp
+		return typed.Format(time.RFC3339Nano)
+	}
+	return value
+}
+
+func toYAMLFloat(number float64) interface{} {
+	if math.IsInf(number, 0) || math.IsNaN(number) {
+		return number
+	}
+	return json.Number(strconv.FormatFloat(number, 'g', -1, 64))
+}
+
+func formatYAMLKey(key interface{}) string {
+	if key == nil {
+		return "null"
+	}
+	return fmt.Sprint(key)
+}
+
+// marshalToYAML encodes the specified value with yaml.v3, indented by
+// yamlIndent, and decodes the encoding into a generic value.
+func marshalToYAML(value interface{}) (encoded string, decoded interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil { // yaml.v3 panics on types it can't encode; e.g., funcs
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	var builder strings.Builder
+	encoder := yaml.NewEncoder(&builder)
+	encoder.SetIndent(len(yamlIndent))
+	if err := encoder.Encode(value); err != nil {
+		return "", nil, err
+	} else if err := encoder.Close(); err != nil {
+		return "", nil, err
+	}
+
+	documents, err := decodeYAML(builder.String())
+	if err != nil {
+		return "", nil, err
+	}
+	return builder.String(), documents[0], nil
+}
+
+// indentLines prefixes every line of the specified text with the specified
+// indentation.
+func indentLines(text, indent string) string {
+	return indent + strings.Replace(strings.TrimSuffix(text, "\n"), "\n", "\n"+indent, -1) + "\n"
+}
@@ -0,0 +1,141 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func ExampleAssertableYAML_Equals_pass() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"key order and styles", "kind: Pod\nmetadata:\n  name: web\n  labels: {app: web}\n",
+			`{"metadata": {"labels": {"app": "web"}, "name": "web"}, "kind": "Pod"}`},
+		{"numbers", "[0x10, 0o20, +16, 1.50, 2e0]", "- 16\n- 16\n- 16\n- 1.5\n- 2\n"},
+		{"quoting", "- plain\n- 'single ''quoted'''\n- \"\\u0041\\tB\"\n- !!str 42\n",
+			"[\"plain\", \"single 'quoted'\", 'A\tB', '42']"},
+		{"block scalars", "literal: |\n  a\n    b\n\nfolded: >-\n  c\n  d\n\n  e\n",
+			"{literal: \"a\\n  b\\n\", folded: \"c d\\ne\"}"},
+		{"anchors and merge keys", "base: &base {cpu: 1, memory: 2}\nweb:\n  <<: *base\n  memory: 4\n",
+			"base: {cpu: 1, memory: 2}\nweb: {cpu: 1, memory: 4}\n"},
+		{"multi-document streams", strings.NewReader("# pods\n---\nkind: Pod\n---\nkind: Service\n"),
+			[]byte("kind: Pod\n...\n---\nkind: Service\n")},
+		{"complex keys", "? name\n: web\n", "name: web\n"},
+		{"multi-line quoted scalars", "'x\n\n  y'\n", `"x\ny"`},
+	}
+
+	for _, c := range cases {
+		if For(t, c.id).ThatActualYAML(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Passed: " + c.id)
+		}
+	}
+	// Output:
+	// Passed: key order and styles
+	// Passed: numbers
+	// Passed: quoting
+	// Passed: block scalars
+	// Passed: anchors and merge keys
+	// Passed: multi-document streams
+	// Passed: complex keys
+	// Passed: multi-line quoted scalars
+}
+
+func ExampleAssertableYAML_Equals_fail() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"different values", "metadata:\n  name: web\n  labels:\n    tier: front\nspec:\n  replicas: 2\n",
+			"metadata:\n  name: api\n  labels: {app: api}\nspec:\n  replicas: 2.5\n"},
+		{"different documents", "kind: Pod\n---\nkind: Service\n", "kind: Pod\n---\nkind: Ingress\n---\nkind: Job\n"},
+	}
+
+	for _, c := range cases {
+		if !mockTestContextToAssert(c.id).ThatActualYAML(c.actual).Equals(c.expected).Passed() {
+			fmt.Println("Assertion failed successfully!")
+		}
+	}
+	// Output:
+	// file:3: [different values] YAML mismatch (4 differences).
+	// /metadata/labels/app: (missing) != "api"
+	// /metadata/labels/tier: "front" != (missing)
+	// /metadata/name: "web" != "api"
+	// /spec/replicas: 2 != 2.5
+	// Assertion failed successfully!
+	// file:3: [different documents] YAML mismatch (2 differences).
+	// (document 2)/kind: "Service" != "Ingress"
+	// (document 3): (missing) != {"kind":"Job"}
+	// Assertion failed successfully!
+}
+
+func ExampleAssertableYAML_Equals_invalidYAML() {
+	cases := []struct {
+		id       string
+		actual   interface{}
+		expected interface{}
+	}{
+		{"bad indentation", "a: 1\n b: 2\n", "a: 1"},
+		{"unterminated flow", "a: [1, 2\nb: 3\n", "a: 1"},
+		{"duplicate key", "a: 1\na: 2\n", "a: 1"},
+		{"unknown alias", "a: *b\n", "a: 1"},
+		{"nested plain mapping", "a: b: c\n", "a: 1"},
+		{"tab indentation", "a:\n\tb: 1\n", "a: 1"},
+		{"invalid expected", "a: 1", 42},
+	}
+
+	for _, c := range cases {
+		mockTestContextToAssert(c.id).ThatActualYAML(c.actual).Equals(c.expected)
+	}
+	// Output:
+	// file:3: [bad indentation] Invalid actual YAML.
+	// Error: yaml: line 2: mapping values are not allowed in this context
+	// file:3: [unterminated flow] Invalid actual YAML.
+	// Error: yaml: line 1: did not find expected ',' or ']'
+	// file:3: [duplicate key] Invalid actual YAML.
+	// Error: yaml: unmarshal errors:
+	//   line 2: mapping key "a" already defined at line 1
+	// file:3: [unknown alias] Invalid actual YAML.
+	// Error: yaml: unknown anchor 'b' referenced
+	// file:3: [nested plain mapping] Invalid actual YAML.
+	// Error: yaml: mapping values are not allowed in this context
+	// file:3: [tab indentation] Invalid actual YAML.
+	// Error: yaml: line 2: found character that cannot start any token
+	// file:3: [invalid expected] Invalid expected YAML.
+	// Error: unsupported YAML document type int; expected []byte, string, or io.Reader
+}
+
+type yamlContainer struct {
+	Name    string            `yaml:"name"`
+	Image   string            `yaml:",omitempty"`
+	Ports   []int             `yaml:"ports,omitempty"`
+	Timeout time.Duration     `yaml:"timeout"`
+	Env     map[string]string `yaml:"env"`
+	Limits  yamlLimits        `yaml:",inline"`
+	Secret  string            `yaml:"-"`
+}
+
+type yamlLimits struct {
+	CPU float64
+}
+
+func ExampleAssertableYAML_EqualsValue() {
+	container := &yamlContainer{Name: "web", Timeout: 1500 * time.Millisecond, Env: map[string]string{"A": "1"},
+		Limits: yamlLimits{CPU: 0.5}, Secret: "hunter2"}
+
+	manifest := "name: web\ntimeout: 1.5s\nenv:\n  A: \"1\"\ncpu: 0.5\n"
+	if For(t).ThatActualYAML(manifest).EqualsValue(container).Passed() {
+		fmt.Println("Passed!")
+	}
+
+	mockTestContextToAssert().ThatActualYAML("name: web\ntimeout: 1s\nenv: {A: 1}\n").EqualsValue(container)
+	// Output:
+	// Passed!
+	// file:3: YAML mismatch (3 differences).
+	// /cpu: (missing) != 0.5
+	// /env/A: 1 != "1"
+	// /timeout: "1s" != "1.5s"
+}
@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
-	"runtime"
-	"strings"
+
+	"github.com/voicera/tester/internal/testname"
 )
 
 const (
@@ -44,6 +44,13 @@ type dataDrivenTest struct {
 //  }
 //
 // The details of the test case struct are left for the tester to specify.
+// Test cases are decoded as json.Unmarshal does, except that values of types
+// with registered converters are converted; e.g., "error": "timeout" decodes
+// into an error field, and "timeout": "1h30m" into a time.Duration field.
+// See RegisterConverter for the built-in converters.
+// Expected errors can be loaded as *assert.ExpectedError values to match
+// error messages partially; e.g., "error": {"contains": "timeout"} or
+// "error": {"regex": "^dial tcp .+: i/o timeout$"}.
 func LoadTestCasesFromDerivedJSONFile(testCasesToLoad interface{}) error {
 	testFunctionName, err := getTestFunctionName()
 	if err != nil {
@@ -65,21 +72,13 @@ func LoadTestCasesFromDerivedJSONFile(testCasesToLoad interface{}) error {
 	if len(test.TestCases) == 0 {
 		return errors.New("ddt: cannot load test cases from " + testFunctionName + ".json")
 	}
-	return json.Unmarshal(test.TestCases, &testCasesToLoad)
+	return unmarshal(test.TestCases, testCasesToLoad)
 }
 
 func getTestFunctionName() (string, error) {
-	for stackFramesToSkip := 2; ; stackFramesToSkip++ {
-		callerProgramCounter, _, _, ok := runtime.Caller(stackFramesToSkip)
-		if !ok {
-			return "", errors.New(cannotGetTestFunctionNameErrorMessage)
-		}
-		functionFullPath := runtime.FuncForPC(callerProgramCounter).Name()
-		functionName := functionFullPath[strings.LastIndex(functionFullPath, ".")+1:]
-		if strings.HasPrefix(functionName, "Test") ||
-			strings.HasPrefix(functionName, "Benchmark") ||
-			strings.HasPrefix(functionName, "Example") {
-			return functionName, nil
-		}
+	functionName, ok := testname.OfCaller()
+	if !ok {
+		return "", errors.New(cannotGetTestFunctionNameErrorMessage)
 	}
+	return functionName, nil
 }
@@ -1,6 +1,7 @@
 package ddt_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -66,3 +67,26 @@ func mustWriteJSONFile(fileName string, content string) {
 		panic(err)
 	}
 }
+
+func TestLoadTestCasesFromDerivedJSONFileWithExpectedErrors(t *testing.T) {
+	mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWithExpectedErrors.json", `{
+  "testCases": [
+    {"id": "exact message", "error": "dial tcp 10.0.0.1:443: i/o timeout"},
+    {"id": "substring", "error": {"contains": "i/o timeout"}},
+    {"id": "prefix", "error": {"prefix": "dial tcp"}},
+    {"id": "regex", "error": {"regex": "^dial tcp [\\d.]+:\\d+: i/o timeout$"}}
+  ]
+}`)
+	var testCases []struct {
+		ID    string                `json:"id"`
+		Error *assert.ExpectedError `json:"error"`
+	}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	if assert.For(t).ThatActualError(err).IsNil().Passed() {
+		assert.For(t).ThatActualCollection(testCases).HasLength(4)
+		for _, c := range testCases {
+			err := errors.New("dial tcp 10.0.0.1:443: i/o timeout")
+			assert.For(t, c.ID).ThatActualError(err).Equals(c.Error)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package ddt
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/voicera/tester/assert"
+)
+
+// Converter converts a JSON value into a value of the type it's registered
+// for; see RegisterConverter.
+type Converter func(value json.RawMessage) (interface{}, error)
+
+var (
+	convertersLock sync.RWMutex
+	converters     = map[reflect.Type]Converter{
+		reflect.TypeOf((*error)(nil)).Elem(): convertToError,
+		reflect.TypeOf(time.Duration(0)):     convertToDuration,
+		reflect.TypeOf(time.Time{}):          convertToTime,
+		reflect.TypeOf(&regexp.Regexp{}):     convertToRegexp,
+	}
+
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonNull            = []byte("null")
+)
+
+// RegisterConverter registers the specified converter to decode JSON values
+// into values of the specified type wherever it's found in test cases that
+// are loaded by this package; e.g., as a struct field, a slice element, or
+// a map value. The converter replaces any converter that's registered for
+// the same type, including the built-in ones, which convert:
+//
+//  - strings into errors, as assert.ErrorString values, and objects into
+//    errors, as *assert.ExpectedError values
+//  - strings (e.g., "1h30m") and numbers of nanoseconds into time.Duration
+//  - RFC 3339 strings into time.Time
+//  - strings like "/pattern/" into *regexp.Regexp
+//
+// Pointers to the specified type are converted as well, and JSON nulls
+// convert into zero values (e.g., nil pointers) without calling converters.
+// The value that the converter returns must be assignable to the specified
+// type. For example:
+//
+//     ddt.RegisterConverter(reflect.TypeOf(big.Int{}), func(value json.RawMessage) (interface{}, error) {
+//         var text string
+//         if err := json.Unmarshal(value, &text); err != nil {
+//             return nil, err
+//         }
+//         number, ok := new(big.Int).SetString(text, 10)
+//         if !ok {
+//             return nil, fmt.Errorf("invalid big.Int %q", text)
+//         }
+//         return *number, nil
+//     })
+func RegisterConverter(targetType reflect.Type, converter Converter) {
+	convertersLock.Lock()
+	defer convertersLock.Unlock()
+	converters[targetType] = converter
+}
+
+func converterOf(targetType reflect.Type) Converter {
+	convertersLock.RLock()
+	defer convertersLock.RUnlock()
+	return converters[targetType]
+}
+
+// unmarshal decodes the specified JSON data into the value that the
+// specified target points to, as json.Unmarshal does, except that values of
+// types that have registered converters are decoded using said converters.
+func unmarshal(data []byte, target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(target)}
+	}
+	return decodeValue("testCases", data, value.Elem())
+}
+
+// decodeValue decodes the specified JSON data into the specified settable
+// value, whose path (e.g., testCases[0].expected) is specified for errors.
+func decodeValue(path string, data json.RawMessage, value reflect.Value) error {
+	if converter := converterOf(value.Type()); converter != nil {
+		return convertValue(path, data, value, converter)
+	} else if !involvesConverters(value.Type(), map[reflect.Type]bool{}) {
+		return decodeJSONValue(path, data, value)
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		} else if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return decodeValue(path, data, value.Elem())
+	case reflect.Struct:
+		return decodeStruct(path, data, value)
+	case reflect.Slice, reflect.Array:
+		return decodeList(path, data, value)
+	case reflect.Map:
+		if isMapKeyType(value.Type().Key()) {
+			return decodeMap(path, data, value)
+		}
+	}
+	return decodeJSONValue(path, data, value)
+}
+
+// involvesConverters returns whether values of the specified type, which
+// haven't been visited yet, may contain values that converters decode; if
+// not, json.Unmarshal decodes them as is, which honors json.Unmarshaler and
+// encoding.TextUnmarshaler implementations and base64-encoded []byte values.
+func involvesConverters(valueType reflect.Type, visited map[reflect.Type]bool) bool {
+	if converterOf(valueType) != nil {
+		return true
+	} else if visited[valueType] || isUnmarshaledAsIs(valueType) {
+		return false
+	}
+
+	visited[valueType] = true
+	switch valueType.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return involvesConverters(valueType.Elem(), visited)
+	case reflect.Map:
+		return isMapKeyType(valueType.Key()) && involvesConverters(valueType.Elem(), visited)
+	case reflect.Struct:
+		for _, field := range jsonFields(valueType) {
+			if involvesConverters(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isUnmarshaledAsIs returns whether json.Unmarshal decodes values of the
+// specified type in a way of their own; i.e., through their UnmarshalJSON or
+// UnmarshalText methods or, for byte slices, from base64-encoded strings.
+func isUnmarshaledAsIs(valueType reflect.Type) bool {
+	pointerType := reflect.PtrTo(valueType)
+	return pointerType.Implements(jsonUnmarshalerType) || pointerType.Implements(textUnmarshalerType) ||
+		(valueType.Kind() == reflect.Slice && valueType.Elem().Kind() == reflect.Uint8)
+}
+
+func convertValue(path string, data json.RawMessage, value reflect.Value, converter Converter) error {
+	if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	converted, err := converter(data)
+	if err != nil {
+		return fmt.Errorf("ddt: cannot convert %s to %s: %v", path, value.Type(), err)
+	} else if converted == nil || !reflect.TypeOf(converted).AssignableTo(value.Type()) {
+		return fmt.Errorf("ddt: cannot convert %s to %s: converter returned a %T", path, value.Type(), converted)
+	}
+	value.Set(reflect.ValueOf(converted))
+	return nil
+}
+
+func decodeJSONValue(path string, data json.RawMessage, value reflect.Value) error {
+	if err := json.Unmarshal(data, value.Addr().Interface()); err != nil {
+		return fmt.Errorf("ddt: cannot decode %s: %v", path, err)
+	}
+	return nil
+}
+
+func decodeStruct(path string, data json.RawMessage, value reflect.Value) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return fmt.Errorf("ddt: cannot decode %s: %v", path, err)
+	} else if properties == nil { // null leaves the struct as is, like json.Unmarshal does
+		return nil
+	}
+
+	fields := jsonFields(value.Type())
+	for name, property := range properties {
+		if field, ok := findJSONField(fields, name); ok {
+			if err := decodeValue(path+"."+name, property, fieldByIndex(value, field.Index)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeList(path string, data json.RawMessage, value reflect.Value) error {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return fmt.Errorf("ddt: cannot decode %s: %v", path, err)
+	}
+
+	if value.Kind() == reflect.Slice {
+		if elements == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		value.Set(reflect.MakeSlice(value.Type(), len(elements), len(elements)))
+	}
+	for i := 0; i < value.Len(); i++ {
+		if i >= len(elements) { // extra array elements are zeroed, like json.Unmarshal does
+			value.Index(i).Set(reflect.Zero(value.Type().Elem()))
+		} else if err := decodeValue(fmt.Sprintf("%s[%d]", path, i), elements[i], value.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeMap(path string, data json.RawMessage, value reflect.Value) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return fmt.Errorf("ddt: cannot decode %s: %v", path, err)
+	} else if properties == nil {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	} else if value.IsNil() {
+		value.Set(reflect.MakeMapWithSize(value.Type(), len(properties)))
+	}
+
+	for key, property := range properties {
+		element := reflect.New(value.Type().Elem()).Elem()
+		if err := decodeValue(path+"."+key, property, element); err != nil {
+			return err
+		}
+		keyValue, err := decodeMapKey(key, value.Type().Key())
+		if err != nil {
+			return fmt.Errorf("ddt: cannot decode key of %s: %v", path+"."+key, err)
+		}
+		value.SetMapIndex(keyValue, element)
+	}
+	return nil
+}
+
+// isMapKeyType returns whether JSON objects can decode into maps with keys of
+// the specified type, which json.Unmarshal requires to be strings, integers,
+// or encoding.TextUnmarshaler implementations.
+func isMapKeyType(keyType reflect.Type) bool {
+	if reflect.PointerTo(keyType).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch keyType.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// decodeMapKey decodes the specified JSON property name into a map key of
+// the specified type as json.Unmarshal does.
+func decodeMapKey(key string, keyType reflect.Type) (reflect.Value, error) {
+	keyValue := reflect.New(keyType)
+	if unmarshaler, ok := keyValue.Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(key)); err != nil {
+			return reflect.Value{}, err
+		}
+		return keyValue.Elem(), nil
+	}
+
+	switch keyType.Kind() {
+	case reflect.String:
+		keyValue.Elem().SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || keyValue.Elem().OverflowInt(number) {
+			return reflect.Value{}, fmt.Errorf("%q is not a valid %v", key, keyType)
+		}
+		keyValue.Elem().SetInt(number)
+	default:
+		number, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || keyValue.Elem().OverflowUint(number) {
+			return reflect.Value{}, fmt.Errorf("%q is not a valid %v", key, keyType)
+		}
+		keyValue.Elem().SetUint(number)
+	}
+	return keyValue.Elem(), nil
+}
+
+// jsonField is a struct field that's decoded from the JSON property with the
+// field's name.
+type jsonField struct {
+	reflect.StructField
+	name string
+}
+
+// jsonFields returns the fields of the specified struct type that JSON
+// properties decode into, including those promoted from embedded structs,
+// named by their json tags or, if not tagged, their names.
+func jsonFields(structType reflect.Type) []*jsonField {
+	fields := []*jsonField{}
+	for _, field := range reflect.VisibleFields(structType) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		isEmbeddedStruct := field.Anonymous && name == "" &&
+			(field.Type.Kind() == reflect.Struct ||
+				(field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct))
+		if !field.IsExported() || name == "-" || isEmbeddedStruct {
+			continue
+		} else if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, &jsonField{StructField: field, name: name})
+	}
+	return fields
+}
+
+// findJSONField finds the field that the specified JSON property decodes
+// into; i.e., the field with the same name or, as json.Unmarshal does,
+// with the same name ignoring case.
+func findJSONField(fields []*jsonField, name string) (*jsonField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// fieldByIndex returns the nested field of the specified struct value whose
+// index is specified, allocating any nil embedded struct pointers on its way.
+func fieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for i, fieldIndex := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(fieldIndex)
+	}
+	return value
+}
+
+func convertToError(value json.RawMessage) (interface{}, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+		expected := &assert.ExpectedError{}
+		return expected, json.Unmarshal(value, expected)
+	}
+
+	var message string
+	if err := json.Unmarshal(value, &message); err != nil {
+		return nil, fmt.Errorf("expected a string or an object; got %s", value)
+	}
+	return assert.ErrorString(message), nil
+}
+
+func convertToDuration(value json.RawMessage) (interface{}, error) {
+	var text string
+	if err := json.Unmarshal(value, &text); err != nil {
+		var nanoseconds int64
+		if json.Unmarshal(value, &nanoseconds) != nil {
+			return nil, fmt.Errorf(`expected a string like "1h30m" or a number of nanoseconds; got %s`, value)
+		}
+		return time.Duration(nanoseconds), nil
+	}
+	return time.ParseDuration(text)
+}
+
+func convertToTime(value json.RawMessage) (interface{}, error) {
+	var text string
+	if err := json.Unmarshal(value, &text); err != nil {
+		return nil, fmt.Errorf("expected an RFC 3339 string; got %s", value)
+	}
+	return time.Parse(time.RFC3339Nano, text)
+}
+
+func convertToRegexp(value json.RawMessage) (interface{}, error) {
+	var text string
+	if err := json.Unmarshal(value, &text); err != nil {
+		return nil, fmt.Errorf(`expected a string like "/pattern/"; got %s`, value)
+	} else if len(text) < 2 || !strings.HasPrefix(text, "/") || !strings.HasSuffix(text, "/") {
+		return nil, fmt.Errorf(`expected a string like "/pattern/"; got %s`, strconv.Quote(text))
+	}
+	return regexp.Compile(text[1 : len(text)-1])
+}
@@ -0,0 +1,244 @@
+package ddt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/voicera/tester/assert"
+	"github.com/voicera/tester/ddt"
+)
+
+type celsius float64
+
+func TestLoadTestCasesFromDerivedJSONFileWithConvertedTypes(t *testing.T) {
+	mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWithConvertedTypes.json", `{
+  "testCases": [
+    {
+      "id": "converted",
+      "error": "connection refused",
+      "timeout": "1h30m",
+      "retryDelays": ["1s", 2000000000],
+      "deadline": "2006-01-02T15:04:05Z",
+      "lastRun": "2006-01-02T15:04:05.5+07:00",
+      "pattern": "/^dial tcp .+$/",
+      "byStage": {"dial": "5s"},
+      "expectedError": {"contains": "refused"},
+      "Embedded": "promoted"
+    },
+    {"id": "nulls", "error": null, "timeout": null, "lastRun": null, "pattern": null, "retryDelays": null}
+  ]
+}`)
+	type embedded struct {
+		Embedded string
+	}
+	var testCases []struct {
+		embedded
+		ID            string                   `json:"id"`
+		Error         error                    `json:"error"`
+		Timeout       time.Duration            `json:"timeout"`
+		RetryDelays   []time.Duration          `json:"retryDelays"`
+		Deadline      time.Time                `json:"deadline"`
+		LastRun       *time.Time               `json:"lastRun"`
+		Pattern       *regexp.Regexp           `json:"pattern"`
+		ByStage       map[string]time.Duration `json:"byStage"`
+		ExpectedError *assert.ExpectedError    `json:"expectedError"`
+	}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	if !assert.For(t).ThatActualError(err).IsNil().Passed() ||
+		!assert.For(t).ThatActualCollection(testCases).HasLength(2).Passed() {
+		return
+	}
+
+	converted := testCases[0]
+	assert.For(t).ThatActual(converted.Error).Equals(assert.ErrorString("connection refused"))
+	assert.For(t).ThatActual(converted.Timeout).Equals(90 * time.Minute)
+	assert.For(t).ThatActual(converted.RetryDelays).Equals([]time.Duration{time.Second, 2 * time.Second})
+	assert.For(t).ThatActual(converted.Deadline).Equals(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC))
+	if assert.For(t).ThatActual(converted.LastRun).IsNotNil().Passed() {
+		expected := time.Date(2006, 1, 2, 8, 4, 5, 5e8, time.UTC)
+		assert.For(t).ThatActual(converted.LastRun.Equal(expected)).IsTrue()
+	}
+	if assert.For(t).ThatActual(converted.Pattern).IsNotNil().Passed() {
+		assert.For(t).ThatActual(converted.Pattern.String()).Equals("^dial tcp .+$")
+	}
+	assert.For(t).ThatActual(converted.ByStage).Equals(map[string]time.Duration{"dial": 5 * time.Second})
+	assert.For(t).ThatActualError(errors.New("connection refused")).Equals(converted.ExpectedError)
+	assert.For(t).ThatActual(converted.Embedded).Equals("promoted")
+
+	nulls := testCases[1]
+	assert.For(t).ThatActualError(nulls.Error).IsNil()
+	assert.For(t).ThatActual(nulls.Timeout).Equals(time.Duration(0))
+	assert.For(t).ThatActual(nulls.LastRun == nil && nulls.Pattern == nil && nulls.RetryDelays == nil).IsTrue()
+}
+
+func TestLoadTestCasesFromDerivedJSONFileWithUnmarshalers(t *testing.T) {
+	mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWithUnmarshalers.json", `{
+  "testCases": [
+    {
+      "data": "aGVsbG8=",
+      "ip": "192.0.2.1",
+      "timeout": "1s",
+      "plain": {"data": "aGk=", "ips": ["::1"]}
+    }
+  ]
+}`)
+	type plain struct {
+		Data []byte   `json:"data"`
+		IPs  []net.IP `json:"ips"`
+	}
+	var testCases []struct {
+		Data    []byte        `json:"data"`
+		IP      net.IP        `json:"ip"`
+		Timeout time.Duration `json:"timeout"`
+		Plain   plain         `json:"plain"`
+	}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	if !assert.For(t).ThatActualError(err).IsNil().Passed() ||
+		!assert.For(t).ThatActualCollection(testCases).HasLength(1).Passed() {
+		return
+	}
+
+	testCase := testCases[0]
+	assert.For(t).ThatActualBytes(testCase.Data).Equals([]byte("hello"))
+	assert.For(t).ThatActual(testCase.IP.Equal(net.IPv4(192, 0, 2, 1))).IsTrue()
+	assert.For(t).ThatActual(testCase.Timeout).Equals(time.Second)
+	assert.For(t).ThatActualBytes(testCase.Plain.Data).Equals([]byte("hi"))
+	if assert.For(t).ThatActualCollection(testCase.Plain.IPs).HasLength(1).Passed() {
+		assert.For(t).ThatActual(testCase.Plain.IPs[0].Equal(net.IPv6loopback)).IsTrue()
+	}
+}
+
+type stage string
+
+func (s *stage) UnmarshalText(text []byte) error {
+	*s = stage(strings.ToUpper(string(text)))
+	return nil
+}
+
+func TestLoadTestCasesFromDerivedJSONFileWithNonStringMapKeys(t *testing.T) {
+	mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWithNonStringMapKeys.json", `{
+  "testCases": [
+    {
+      "errorsByCode": {"-1": "refused", "404": null},
+      "delaysByAttempt": {"1": "1s", "2": 2000000000},
+      "timeoutsByStage": {"dial": "5s"}
+    }
+  ]
+}`)
+	var testCases []struct {
+		ErrorsByCode    map[int]error           `json:"errorsByCode"`
+		DelaysByAttempt map[uint8]time.Duration `json:"delaysByAttempt"`
+		TimeoutsByStage map[stage]time.Duration `json:"timeoutsByStage"`
+	}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	if !assert.For(t).ThatActualError(err).IsNil().Passed() ||
+		!assert.For(t).ThatActualCollection(testCases).HasLength(1).Passed() {
+		return
+	}
+
+	testCase := testCases[0]
+	assert.For(t).ThatActual(testCase.ErrorsByCode).Equals(
+		map[int]error{-1: assert.ErrorString("refused"), 404: nil})
+	assert.For(t).ThatActual(testCase.DelaysByAttempt).Equals(
+		map[uint8]time.Duration{1: time.Second, 2: 2 * time.Second})
+	assert.For(t).ThatActual(testCase.TimeoutsByStage).Equals(map[stage]time.Duration{"DIAL": 5 * time.Second})
+
+	mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWithNonStringMapKeys.json",
+		`{"testCases": [{"delaysByAttempt": {"256": "1s"}}]}`)
+	err = ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	assert.For(t).ThatActualError(err).FormatsAs(
+		`ddt: cannot decode key of testCases[0].delaysByAttempt.256: "256" is not a valid uint8`)
+}
+
+func TestLoadTestCasesFromDerivedJSONFileWhenConversionFails(t *testing.T) {
+	cases := []struct {
+		id       string
+		property string
+		expected string
+	}{
+		{"error", `"error": 42`,
+			"ddt: cannot convert testCases[0].error to error: expected a string or an object; got 42"},
+		{"expected error", `"error": {"regex": "("}`,
+			"ddt: cannot convert testCases[0].error to error: " +
+				"assert: cannot decode expected error: error parsing regexp: missing closing ): `(`"},
+		{"duration", `"timeout": "90 minutes"`,
+			`ddt: cannot convert testCases[0].timeout to time.Duration: ` +
+				`time: unknown unit " minutes" in duration "90 minutes"`},
+		{"time", `"deadline": "2006-01-02"`,
+			`ddt: cannot convert testCases[0].deadline to time.Time: ` +
+				`parsing time "2006-01-02" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "" as "T"`},
+		{"regexp without slashes", `"pattern": "^dial"`,
+			`ddt: cannot convert testCases[0].pattern to *regexp.Regexp: ` +
+				`expected a string like "/pattern/"; got "^dial"`},
+		{"other types", `"id": 42`,
+			"ddt: cannot decode testCases[0].id: json: cannot unmarshal number into Go value of type string"},
+	}
+
+	for _, c := range cases {
+		mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWhenConversionFails.json",
+			`{"testCases": [{`+c.property+`}]}`)
+		var testCases []struct {
+			ID       string         `json:"id"`
+			Error    error          `json:"error"`
+			Timeout  time.Duration  `json:"timeout"`
+			Deadline time.Time      `json:"deadline"`
+			Pattern  *regexp.Regexp `json:"pattern"`
+		}
+		err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+		assert.For(t, c.id).ThatActualError(err).FormatsAs(c.expected)
+	}
+}
+
+func TestLoadTestCasesFromDerivedJSONFileWhenTargetIsNotAPointer(t *testing.T) {
+	mustWriteJSONFile("TestLoadTestCasesFromDerivedJSONFileWhenTargetIsNotAPointer.json", `{"testCases": []}`)
+	var testCases []struct{}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(testCases)
+	assert.For(t).ThatActualError(err).FormatsAs("json: Unmarshal(non-pointer []struct {})")
+}
+
+func TestRegisterConverter(t *testing.T) {
+	ddt.RegisterConverter(reflect.TypeOf(celsius(0)), func(value json.RawMessage) (interface{}, error) {
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			return nil, err
+		}
+		var degrees float64
+		_, err := fmt.Sscanf(strings.TrimSuffix(text, "°C"), "%g", &degrees)
+		return celsius(degrees), err
+	})
+	mustWriteJSONFile("TestRegisterConverter.json", `{"testCases": [{"temperatures": ["21.5°C", "-4°C"]}]}`)
+
+	var testCases []struct {
+		Temperatures []*celsius `json:"temperatures"`
+	}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	if assert.For(t).ThatActualError(err).IsNil().Passed() &&
+		assert.For(t).ThatActualCollection(testCases).HasLength(1).Passed() &&
+		assert.For(t).ThatActualCollection(testCases[0].Temperatures).HasLength(2).Passed() {
+		assert.For(t).ThatActual(*testCases[0].Temperatures[0]).Equals(celsius(21.5))
+		assert.For(t).ThatActual(*testCases[0].Temperatures[1]).Equals(celsius(-4))
+	}
+}
+
+func TestRegisterConverterWhenConverterReturnsTheWrongType(t *testing.T) {
+	type fahrenheit float64
+	ddt.RegisterConverter(reflect.TypeOf(fahrenheit(0)), func(value json.RawMessage) (interface{}, error) {
+		return 70.0, nil
+	})
+	mustWriteJSONFile("TestRegisterConverterWhenConverterReturnsTheWrongType.json",
+		`{"testCases": [{"temperature": "70°F"}]}`)
+
+	var testCases []struct {
+		Temperature fahrenheit `json:"temperature"`
+	}
+	err := ddt.LoadTestCasesFromDerivedJSONFile(&testCases)
+	assert.For(t).ThatActualError(err).FormatsAs(
+		"ddt: cannot convert testCases[0].temperature to ddt_test.fahrenheit: converter returned a float64")
+}
@@ -0,0 +1,26 @@
+// Package testname finds the test function that's running, for packages of
+// the tester that derive the paths of test data from it.
+package testname
+
+import (
+	"runtime"
+	"strings"
+)
+
+// OfCaller returns the name of the test, benchmark, or example function that
+// is calling (e.g., "TestRender"), directly or not; it returns false if no
+// such function is calling.
+func OfCaller() (string, bool) {
+	for skip := 2; ; skip++ {
+		programCounter, _, _, ok := runtime.Caller(skip)
+		if !ok {
+			return "", false
+		}
+		fullName := runtime.FuncForPC(programCounter).Name()
+		name := fullName[strings.LastIndex(fullName, ".")+1:]
+		if strings.HasPrefix(name, "Test") || strings.HasPrefix(name, "Benchmark") ||
+			strings.HasPrefix(name, "Example") {
+			return name, true
+		}
+	}
+}